@@ -0,0 +1,149 @@
+package testrequest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+type (
+	// A bodySource provides the request's body.
+	bodySource interface {
+		// open returns a reader of the body.
+		open() (io.Reader, error)
+		// replayable reports whether every call of open returns a new reader of the whole body.
+		replayable() bool
+	}
+	// A sizedBody is a bodySource of known size.
+	sizedBody interface {
+		size() int64
+	}
+	// A typedBody is a bodySource with its own Content-Type.
+	typedBody interface {
+		contentType() string
+	}
+	// bytesBody is an in-memory body which can be read any number of times.
+	bytesBody []byte
+	// typedBytesBody is an in-memory body with its own Content-Type.
+	typedBytesBody struct {
+		bytesBody
+		mediaType string
+	}
+	// readerBody is a one-shot body read from the reader set with SetBody.
+	readerBody struct {
+		r io.Reader
+	}
+)
+
+func (b *requestBuilder) SetBodyBytes(data []byte) RequestBuilder {
+	b.body = bytesBody(data)
+	return b
+}
+
+func (b *requestBuilder) SetBodyString(s string) RequestBuilder {
+	b.body = bytesBody(s)
+	return b
+}
+
+func (b *requestBuilder) RepeatableRequest() *http.Request {
+	b.bufferBody()
+	return b.Request()
+}
+
+// bufferBody reads a one-shot body into memory, so it can be read again.
+func (b *requestBuilder) bufferBody() {
+	rb, ok := b.body.(*readerBody)
+	if !ok {
+		return
+	}
+	if isFaultBody(rb) {
+		b.addError("SetBody", errFaultBody)
+		b.body = nil
+		return
+	}
+	data, err := io.ReadAll(rb.r)
+	if err != nil {
+		b.addError("SetBody", err)
+	}
+	b.body = bytesBody(data)
+}
+
+// source returns the bodySource of the request's body with the JSON edits applied
+// and encoded in the content codings if set.
+func (b *requestBuilder) source() (bodySource, error) {
+	body, err := b.baseSource()
+	if err != nil {
+		return nil, err
+	}
+	if len(b.jsonEdits) > 0 {
+		if body, err = b.editJSON(body); err != nil {
+			return nil, err
+		}
+	}
+	if len(b.contentEncodings) == 0 {
+		return body, nil
+	}
+	return b.encodeBody(body)
+}
+
+// baseSource returns the bodySource of the request's body set by the builder's setters.
+func (b *requestBuilder) baseSource() (bodySource, error) {
+	switch {
+	case b.postForm != nil && b.multipart != nil:
+		return nil, errors.New("testrequest: both PostForm and multipart form are set")
+	case b.postForm != nil:
+		return bytesBody(b.postForm.Encode()), nil
+	case b.multipart != nil:
+		body, err := b.multipart.encode()
+		if err != nil {
+			return nil, fmt.Errorf("testrequest: multipart form: %w", err)
+		}
+		return body, nil
+	}
+	return b.body, nil
+}
+
+// openBody returns the reader of the body and,
+// if the body is replayable, the function for http.Request.GetBody.
+func openBody(body bodySource) (io.Reader, func() (io.ReadCloser, error), error) {
+	if body == nil {
+		return nil, nil, nil
+	}
+	r, err := body.open()
+	if err != nil || !body.replayable() {
+		return r, nil, err
+	}
+	getBody := func() (io.ReadCloser, error) {
+		r, err := body.open()
+		if err != nil {
+			return nil, err
+		}
+		if rc, ok := r.(io.ReadCloser); ok {
+			return rc, nil
+		}
+		return io.NopCloser(r), nil
+	}
+	return r, getBody, nil
+}
+
+func (b bytesBody) open() (io.Reader, error) {
+	return bytes.NewReader(b), nil
+}
+
+func (b bytesBody) replayable() bool {
+	return true
+}
+
+func (b *readerBody) open() (io.Reader, error) {
+	return b.r, nil
+}
+
+func (b *readerBody) replayable() bool {
+	return false
+}
+
+func (b typedBytesBody) contentType() string {
+	return b.mediaType
+}
@@ -0,0 +1,140 @@
+package testrequest
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func Test_requestBuilder_GetBody(t *testing.T) {
+	tests := []struct {
+		name        string
+		builder     RequestBuilder
+		want        string
+		wantGetBody bool
+	}{
+		{
+			name:        "Bytes",
+			builder:     Builder().SetBodyBytes([]byte("test")),
+			want:        "test",
+			wantGetBody: true,
+		},
+		{
+			name:        "String",
+			builder:     Builder().SetBodyString("test"),
+			want:        "test",
+			wantGetBody: true,
+		},
+		{
+			name:        "JSON",
+			builder:     Builder().SetJSON([]byte("{}")),
+			want:        "{}",
+			wantGetBody: true,
+		},
+		{
+			name:        "PostForm",
+			builder:     Builder().SetPostForm(url.Values{"test": {"test"}}),
+			want:        "test=test",
+			wantGetBody: true,
+		},
+		{
+			name:        "StringsReader",
+			builder:     Builder().SetBody(strings.NewReader("test")),
+			want:        "test",
+			wantGetBody: true,
+		},
+		{
+			name:        "BytesReader",
+			builder:     Builder().SetBody(bytes.NewReader([]byte("test"))),
+			want:        "test",
+			wantGetBody: true,
+		},
+		{
+			name:        "BytesBuffer",
+			builder:     Builder().SetBody(bytes.NewBufferString("test")),
+			want:        "test",
+			wantGetBody: true,
+		},
+		{
+			name:        "Reader",
+			builder:     Builder().SetBody(io.MultiReader(strings.NewReader("test"))),
+			want:        "test",
+			wantGetBody: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := tt.builder.Request()
+			got, _ := io.ReadAll(req.Body)
+			if string(got) != tt.want {
+				t.Errorf("Request() body = %v, want %v", string(got), tt.want)
+			}
+			if (req.GetBody != nil) != tt.wantGetBody {
+				t.Errorf("Request() getBody = %v, want %v", req.GetBody != nil, tt.wantGetBody)
+				return
+			}
+			if !tt.wantGetBody {
+				return
+			}
+			for i := 0; i < 2; i++ {
+				body, err := req.GetBody()
+				if err != nil {
+					t.Errorf("GetBody() error = %v", err)
+					return
+				}
+				got, _ = io.ReadAll(body)
+				if string(got) != tt.want {
+					t.Errorf("GetBody() = %v, want %v", string(got), tt.want)
+				}
+			}
+		})
+	}
+}
+
+func Test_requestBuilder_RequestRepeatedly(t *testing.T) {
+	b := Builder().SetJSON([]byte("{}"))
+	first, second := b.Request(), b.Request()
+	io.ReadAll(first.Body)
+	got, _ := io.ReadAll(second.Body)
+	if string(got) != "{}" {
+		t.Errorf("Request() body = %v, want %v", string(got), "{}")
+	}
+}
+
+func Test_requestBuilder_RepeatableRequest(t *testing.T) {
+	b := Builder().SetBody(io.MultiReader(strings.NewReader("test")))
+	for i := 0; i < 2; i++ {
+		req := b.RepeatableRequest()
+		got, _ := io.ReadAll(req.Body)
+		if string(got) != "test" {
+			t.Errorf("RepeatableRequest() body = %v, want %v", string(got), "test")
+		}
+		if req.GetBody == nil {
+			t.Errorf("RepeatableRequest() getBody is nil")
+		}
+	}
+}
+
+func Test_requestBuilder_SetBodyNil(t *testing.T) {
+	b := Builder().SetBodyString("test").SetBody(nil)
+	for _, req := range []*http.Request{b.Clone().Request(), b.RepeatableRequest()} {
+		got, _ := io.ReadAll(req.Body)
+		if string(got) != "" {
+			t.Errorf("SetBody() body = %v, want %v", string(got), "")
+		}
+	}
+}
+
+func Test_requestBuilder_SetBodyBuffer(t *testing.T) {
+	buf := bytes.NewBufferString("test")
+	b := Builder().SetBody(buf)
+	buf.Reset()
+	buf.WriteString("xyz!")
+	got, _ := io.ReadAll(b.Request().Body)
+	if string(got) != "test" {
+		t.Errorf("SetBody() body = %v, want %v", string(got), "test")
+	}
+}
@@ -0,0 +1,56 @@
+package testrequest
+
+import (
+	"fmt"
+	"net"
+)
+
+// A ProxyHeader is a header which reports the client's IP address
+// when a request is forwarded by a proxy.
+type ProxyHeader string
+
+const (
+	// XForwardedFor is the de facto standard X-Forwarded-For header.
+	XForwardedFor ProxyHeader = "X-Forwarded-For"
+	// XRealIP is the X-Real-Ip header.
+	XRealIP ProxyHeader = "X-Real-Ip"
+	// Forwarded is the Forwarded header. See RFC 7239.
+	Forwarded ProxyHeader = "Forwarded"
+)
+
+// remoteAddrPort is the port of the remote address set by httptest.NewRequest.
+const remoteAddrPort = "1234"
+
+func (b *requestBuilder) SetRemoteAddr(addr string) RequestBuilder {
+	b.remoteAddr = addr
+	return b
+}
+
+func (b *requestBuilder) SetHost(host string) RequestBuilder {
+	b.host = host
+	return b
+}
+
+func (b *requestBuilder) SetClientIP(ip string, headers ...ProxyHeader) RequestBuilder {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return b.addError("SetClientIP", fmt.Errorf("invalid IP address %q", ip))
+	}
+	ip = parsed.String()
+	if len(headers) == 0 {
+		return b.SetRemoteAddr(net.JoinHostPort(ip, remoteAddrPort))
+	}
+	for _, h := range headers {
+		switch h {
+		case Forwarded:
+			node := ip
+			if parsed.To4() == nil {
+				node = `"[` + ip + `]"`
+			}
+			b.SetHeader(string(h), "for="+node)
+		default:
+			b.SetHeader(string(h), ip)
+		}
+	}
+	return b
+}
@@ -0,0 +1,97 @@
+package testrequest
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func Test_requestBuilder_SetRemoteAddr(t *testing.T) {
+	want := "198.51.100.7:54321"
+	req := Builder().SetRemoteAddr(want).Request()
+	if req.RemoteAddr != want {
+		t.Errorf("SetRemoteAddr() = %v, want %v", req.RemoteAddr, want)
+	}
+}
+
+func Test_requestBuilder_SetHost(t *testing.T) {
+	req := Builder().
+		SetTarget("https://server.test/books").
+		SetHost("books.test").
+		Request()
+	if req.Host != "books.test" {
+		t.Errorf("SetHost() = %v, want %v", req.Host, "books.test")
+	}
+	if req.URL.Host != "server.test" {
+		t.Errorf("SetHost() urlHost = %v, want %v", req.URL.Host, "server.test")
+	}
+}
+
+func Test_requestBuilder_SetClientIP(t *testing.T) {
+	type args struct {
+		ip      string
+		headers []ProxyHeader
+	}
+	tests := []struct {
+		name           string
+		args           args
+		wantRemoteAddr string
+		wantHeader     http.Header
+		wantErr        bool
+	}{
+		{
+			name:           "RemoteAddr",
+			args:           args{ip: "198.51.100.7"},
+			wantRemoteAddr: "198.51.100.7:1234",
+			wantHeader:     http.Header{},
+		},
+		{
+			name:           "IPv6RemoteAddr",
+			args:           args{ip: "2001:db8::1"},
+			wantRemoteAddr: "[2001:db8::1]:1234",
+			wantHeader:     http.Header{},
+		},
+		{
+			name:           "ProxyHeaders",
+			args:           args{ip: "198.51.100.7", headers: []ProxyHeader{XForwardedFor, XRealIP, Forwarded}},
+			wantRemoteAddr: "192.0.2.1:1234",
+			wantHeader: http.Header{
+				"X-Forwarded-For": {"198.51.100.7"},
+				"X-Real-Ip":       {"198.51.100.7"},
+				"Forwarded":       {"for=198.51.100.7"},
+			},
+		},
+		{
+			name:           "IPv6ProxyHeaders",
+			args:           args{ip: "2001:DB8::1", headers: []ProxyHeader{XForwardedFor, Forwarded}},
+			wantRemoteAddr: "192.0.2.1:1234",
+			wantHeader: http.Header{
+				"X-Forwarded-For": {"2001:db8::1"},
+				"Forwarded":       {`for="[2001:db8::1]"`},
+			},
+		},
+		{
+			name:    "InvalidIP",
+			args:    args{ip: "198.51.100"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := Builder().SetClientIP(tt.args.ip, tt.args.headers...).Build()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SetClientIP() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if req.RemoteAddr != tt.wantRemoteAddr {
+				t.Errorf("SetClientIP() remoteAddr = %v, want %v", req.RemoteAddr, tt.wantRemoteAddr)
+			}
+			if !reflect.DeepEqual(req.Header, tt.wantHeader) {
+				t.Errorf("SetClientIP() header = %v, want %v", req.Header, tt.wantHeader)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package testrequest
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"mime"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+type (
+	// A Codec encodes values as request bodies of its media type.
+	Codec interface {
+		// MediaType returns the media type of the encoded data, e.g. application/msgpack.
+		MediaType() string
+		// Marshal returns the encoding of the value.
+		Marshal(v interface{}) ([]byte, error)
+	}
+	jsonCodec struct{}
+	xmlCodec  struct{}
+	formCodec struct{}
+)
+
+var codecs = struct {
+	sync.RWMutex
+	m map[string]Codec
+}{
+	m: map[string]Codec{
+		"application/json":                  jsonCodec{},
+		"application/xml":                   xmlCodec{},
+		"application/x-www-form-urlencoded": formCodec{},
+	},
+}
+
+// RegisterCodec registers the codec for its media type, replacing a codec registered before.
+// JSON, XML and form codecs are registered by default.
+//
+// RegisterCodec is usually called in an init function or TestMain.
+func RegisterCodec(c Codec) {
+	codecs.Lock()
+	defer codecs.Unlock()
+	codecs.m[baseMediaType(c.MediaType())] = c
+}
+
+// lookupCodec returns the codec registered for the media type, ignoring its parameters.
+func lookupCodec(mediaType string) (Codec, bool) {
+	codecs.RLock()
+	defer codecs.RUnlock()
+	c, ok := codecs.m[baseMediaType(mediaType)]
+	return c, ok
+}
+
+// baseMediaType returns the media type without parameters in lower case.
+func baseMediaType(mediaType string) string {
+	if t, _, err := mime.ParseMediaType(mediaType); err == nil {
+		return t
+	}
+	return strings.ToLower(strings.TrimSpace(mediaType))
+}
+
+func (b *requestBuilder) SetBodyFromValue(mediaType string, v interface{}) RequestBuilder {
+	c, ok := lookupCodec(mediaType)
+	if !ok {
+		return b.addError("SetBodyFromValue", fmt.Errorf("no codec registered for %q", mediaType))
+	}
+	data, err := c.Marshal(v)
+	if err != nil {
+		return b.addError("SetBodyFromValue", err)
+	}
+	return b.setPayload(data, mediaType)
+}
+
+func (b *requestBuilder) SetBodyWithCodec(c Codec, v interface{}) RequestBuilder {
+	data, err := c.Marshal(v)
+	if err != nil {
+		return b.addError("SetBodyWithCodec", err)
+	}
+	return b.setPayload(data, c.MediaType())
+}
+
+func (jsonCodec) MediaType() string {
+	return "application/json"
+}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (xmlCodec) MediaType() string {
+	return "application/xml"
+}
+
+func (xmlCodec) Marshal(v interface{}) ([]byte, error) {
+	return xml.Marshal(v)
+}
+
+func (formCodec) MediaType() string {
+	return "application/x-www-form-urlencoded"
+}
+
+// Marshal encodes url.Values, map[string][]string, map[string]string
+// or a struct like SetPostFormFromValue.
+func (formCodec) Marshal(v interface{}) ([]byte, error) {
+	switch v := v.(type) {
+	case url.Values:
+		return []byte(v.Encode()), nil
+	case map[string][]string:
+		return []byte(url.Values(v).Encode()), nil
+	case map[string]string:
+		values := make(url.Values, len(v))
+		for key, value := range v {
+			values.Set(key, value)
+		}
+		return []byte(values.Encode()), nil
+	}
+	values, err := encodeForm(v)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(values.Encode()), nil
+}
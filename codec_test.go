@@ -0,0 +1,118 @@
+package testrequest
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+type upperCodec struct{}
+
+func (upperCodec) MediaType() string {
+	return "text/x-upper"
+}
+
+func (upperCodec) Marshal(v interface{}) ([]byte, error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, errors.New("not a string")
+	}
+	return []byte(strings.ToUpper(s)), nil
+}
+
+func Test_requestBuilder_SetBodyFromValue(t *testing.T) {
+	RegisterCodec(upperCodec{})
+	tests := []struct {
+		name            string
+		builder         RequestBuilder
+		wantMethod      string
+		wantContentType string
+		wantBody        string
+		wantErr         bool
+	}{
+		{
+			name:            "JSON",
+			builder:         Builder().SetBodyFromValue("application/json", map[string]string{"test": "test"}),
+			wantMethod:      http.MethodPost,
+			wantContentType: "application/json",
+			wantBody:        `{"test":"test"}`,
+		},
+		{
+			name:            "XML",
+			builder:         Builder().SetBodyFromValue("application/xml; charset=UTF-8", xmlBook{Title: "test"}),
+			wantMethod:      http.MethodPost,
+			wantContentType: "application/xml; charset=UTF-8",
+			wantBody:        "<book><title>test</title></book>",
+		},
+		{
+			name:            "Form",
+			builder:         Builder().SetMethod(http.MethodPut).SetBodyFromValue("application/x-www-form-urlencoded", url.Values{"test": {"test"}}),
+			wantMethod:      http.MethodPut,
+			wantContentType: "application/x-www-form-urlencoded",
+			wantBody:        "test=test",
+		},
+		{
+			name:            "FormStruct",
+			builder:         Builder().SetBodyFromValue("application/x-www-form-urlencoded", formAddress{City: "Berlin"}),
+			wantMethod:      http.MethodPost,
+			wantContentType: "application/x-www-form-urlencoded",
+			wantBody:        "city=Berlin",
+		},
+		{
+			name:            "Registered",
+			builder:         Builder().SetBodyFromValue("TEXT/X-UPPER", "test"),
+			wantMethod:      http.MethodPost,
+			wantContentType: "TEXT/X-UPPER",
+			wantBody:        "TEST",
+		},
+		{
+			name:    "NotRegistered",
+			builder: Builder().SetBodyFromValue("application/cbor", "test"),
+			wantErr: true,
+		},
+		{
+			name:    "InvalidValue",
+			builder: Builder().SetBodyFromValue("application/x-www-form-urlencoded", 1),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := tt.builder.Build()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SetBodyFromValue() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if req.Method != tt.wantMethod {
+				t.Errorf("SetBodyFromValue() method = %v, want %v", req.Method, tt.wantMethod)
+			}
+			if got := req.Header.Get("Content-Type"); got != tt.wantContentType {
+				t.Errorf("SetBodyFromValue() contentType = %v, want %v", got, tt.wantContentType)
+			}
+			got, _ := io.ReadAll(req.Body)
+			if string(got) != tt.wantBody {
+				t.Errorf("SetBodyFromValue() = %v, want %v", string(got), tt.wantBody)
+			}
+		})
+	}
+}
+
+func Test_requestBuilder_SetBodyWithCodec(t *testing.T) {
+	req := Builder().SetBodyWithCodec(upperCodec{}, "test").Request()
+	got, _ := io.ReadAll(req.Body)
+	if string(got) != "TEST" {
+		t.Errorf("SetBodyWithCodec() = %v, want %v", string(got), "TEST")
+	}
+	if req.Header.Get("Content-Type") != "text/x-upper" {
+		t.Errorf("SetBodyWithCodec() contentType = %v, want %v", req.Header.Get("Content-Type"), "text/x-upper")
+	}
+	if _, err := Builder().SetBodyWithCodec(upperCodec{}, 1).Build(); err == nil {
+		t.Errorf("SetBodyWithCodec() error = %v, wantErr %v", err, true)
+	}
+}
@@ -0,0 +1,101 @@
+package testrequest
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// An Encoder returns a writer which encodes data written to it in a content coding
+// and writes it to w, e.g. a brotli or zstd compressor.
+type Encoder func(w io.Writer) (io.WriteCloser, error)
+
+var encoders = struct {
+	sync.RWMutex
+	m map[string]Encoder
+}{
+	m: map[string]Encoder{
+		"gzip": func(w io.Writer) (io.WriteCloser, error) {
+			return gzip.NewWriter(w), nil
+		},
+		"deflate": func(w io.Writer) (io.WriteCloser, error) {
+			return zlib.NewWriter(w), nil
+		},
+	},
+}
+
+// RegisterEncoder registers the encoder for the content coding, e.g. br or zstd,
+// replacing an encoder registered before. The gzip and deflate encoders are registered by default.
+//
+// RegisterEncoder is usually called in an init function or TestMain.
+func RegisterEncoder(coding string, enc Encoder) {
+	encoders.Lock()
+	defer encoders.Unlock()
+	encoders.m[strings.ToLower(coding)] = enc
+}
+
+func lookupEncoder(coding string) (Encoder, bool) {
+	encoders.RLock()
+	defer encoders.RUnlock()
+	enc, ok := encoders.m[strings.ToLower(coding)]
+	return enc, ok
+}
+
+func (b *requestBuilder) SetContentEncoding(codings ...string) RequestBuilder {
+	b.contentEncodings = append([]string(nil), codings...)
+	if len(codings) == 0 {
+		return b.DelHeader("Content-Encoding")
+	}
+	return b.SetHeader("Content-Encoding", strings.Join(codings, ", "))
+}
+
+func (b *requestBuilder) CorruptContentEncoding() RequestBuilder {
+	b.corruptEncoding = true
+	return b
+}
+
+// encodeBody reads the body into memory and encodes it in the content codings.
+func (b *requestBuilder) encodeBody(body bodySource) (bodySource, error) {
+	if isFaultBody(body) {
+		return nil, fmt.Errorf("testrequest: SetContentEncoding: %w", errFaultBody)
+	}
+	var data []byte
+	if body != nil {
+		r, err := body.open()
+		if err != nil {
+			return nil, err
+		}
+		if data, err = io.ReadAll(r); err != nil {
+			return nil, fmt.Errorf("testrequest: SetContentEncoding: %w", err)
+		}
+	}
+	for _, coding := range b.contentEncodings {
+		enc, ok := lookupEncoder(coding)
+		if !ok {
+			return nil, fmt.Errorf("testrequest: SetContentEncoding: no encoder registered for %q", coding)
+		}
+		var buf bytes.Buffer
+		w, err := enc(&buf)
+		if err != nil {
+			return nil, fmt.Errorf("testrequest: SetContentEncoding: %w", err)
+		}
+		if _, err = w.Write(data); err == nil {
+			err = w.Close()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("testrequest: SetContentEncoding: %w", err)
+		}
+		data = buf.Bytes()
+	}
+	if b.corruptEncoding {
+		data = data[:len(data)/2]
+	}
+	if typed, ok := body.(typedBody); ok {
+		return typedBytesBody{bytesBody: data, mediaType: typed.contentType()}, nil
+	}
+	return bytesBody(data), nil
+}
@@ -0,0 +1,107 @@
+package testrequest
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/url"
+	"testing"
+)
+
+type upperWriter struct {
+	w io.Writer
+}
+
+func (u upperWriter) Write(p []byte) (int, error) {
+	return u.w.Write(bytes.ToUpper(p))
+}
+
+func (u upperWriter) Close() error {
+	return nil
+}
+
+func Test_requestBuilder_SetContentEncoding(t *testing.T) {
+	req := Builder().SetJSON([]byte(`{"test":"test"}`)).SetContentEncoding("gzip").Request()
+	if got := req.Header.Get("Content-Encoding"); got != "gzip" {
+		t.Errorf("SetContentEncoding() header = %v, want %v", got, "gzip")
+	}
+	if got := req.Header.Get("Content-Type"); got != "application/json;charset=UTF-8" {
+		t.Errorf("SetContentEncoding() contentType = %v", got)
+	}
+	zr, err := gzip.NewReader(req.Body)
+	if err != nil {
+		t.Errorf("SetContentEncoding() gzip error = %v", err)
+		return
+	}
+	got, err := io.ReadAll(zr)
+	if err != nil || string(got) != `{"test":"test"}` {
+		t.Errorf("SetContentEncoding() = %v, %v, want %v", string(got), err, `{"test":"test"}`)
+	}
+	if req.GetBody == nil {
+		t.Errorf("SetContentEncoding() getBody is nil")
+	}
+}
+
+func Test_requestBuilder_SetContentEncodingStacked(t *testing.T) {
+	req := Builder().
+		SetPostForm(url.Values{"test": {"test"}}).
+		SetContentEncoding("gzip", "deflate").
+		Request()
+	if got := req.Header.Get("Content-Encoding"); got != "gzip, deflate" {
+		t.Errorf("SetContentEncoding() header = %v, want %v", got, "gzip, deflate")
+	}
+	zr, err := zlib.NewReader(req.Body)
+	if err != nil {
+		t.Errorf("SetContentEncoding() deflate error = %v", err)
+		return
+	}
+	gr, err := gzip.NewReader(zr)
+	if err != nil {
+		t.Errorf("SetContentEncoding() gzip error = %v", err)
+		return
+	}
+	got, _ := io.ReadAll(gr)
+	if string(got) != "test=test" {
+		t.Errorf("SetContentEncoding() = %v, want %v", string(got), "test=test")
+	}
+}
+
+func TestRegisterEncoder(t *testing.T) {
+	RegisterEncoder("x-upper", func(w io.Writer) (io.WriteCloser, error) {
+		return upperWriter{w: w}, nil
+	})
+	req := Builder().SetBodyString("test").SetContentEncoding("X-Upper").Request()
+	got, _ := io.ReadAll(req.Body)
+	if string(got) != "TEST" {
+		t.Errorf("RegisterEncoder() = %v, want %v", string(got), "TEST")
+	}
+	if _, err := Builder().SetBodyString("test").SetContentEncoding("br").Build(); err == nil {
+		t.Errorf("SetContentEncoding() error = %v, wantErr %v", err, true)
+	}
+}
+
+func Test_requestBuilder_CorruptContentEncoding(t *testing.T) {
+	for _, coding := range []string{"gzip", "deflate"} {
+		t.Run(coding, func(t *testing.T) {
+			req := Builder().
+				SetBodyString("test test test test").
+				SetContentEncoding(coding).
+				CorruptContentEncoding().
+				Request()
+			var r io.Reader
+			var err error
+			if coding == "gzip" {
+				r, err = gzip.NewReader(req.Body)
+			} else {
+				r, err = zlib.NewReader(req.Body)
+			}
+			if err == nil {
+				_, err = io.ReadAll(r)
+			}
+			if err == nil {
+				t.Errorf("CorruptContentEncoding() error = %v, wantErr %v", err, true)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package testrequest
+
+import (
+	"errors"
+	"io"
+	"net/http"
+)
+
+type contentLengthMode int
+
+const (
+	contentLengthDefault contentLengthMode = iota
+	contentLengthDeclared
+	contentLengthUnknown
+	contentLengthMismatch
+)
+
+// contentLengthReader reads the body as net/http server does for the declared ContentLength:
+// it stops after the declared length and reports io.ErrUnexpectedEOF if the body is shorter.
+type contentLengthReader struct {
+	io.ReadCloser
+	remaining int64
+}
+
+func (b *requestBuilder) SetContentLength(n int64) RequestBuilder {
+	b.contentLength = n
+	b.contentLengthMode = contentLengthDeclared
+	return b
+}
+
+func (b *requestBuilder) UnknownContentLength() RequestBuilder {
+	b.contentLengthMode = contentLengthUnknown
+	return b
+}
+
+func (b *requestBuilder) SetContentLengthMismatch(delta int64) RequestBuilder {
+	b.contentLength = delta
+	b.contentLengthMode = contentLengthMismatch
+	return b
+}
+
+// setContentLength sets the request's ContentLength and limits the body,
+// and the one returned by GetBody, to the declared length.
+func (b *requestBuilder) setContentLength(req *http.Request) error {
+	n := b.contentLength
+	switch b.contentLengthMode {
+	case contentLengthDefault:
+		return nil
+	case contentLengthUnknown:
+		req.ContentLength = -1
+		return nil
+	case contentLengthMismatch:
+		if req.ContentLength < 0 {
+			return errors.New("testrequest: SetContentLengthMismatch: length of the body is unknown")
+		}
+		n += req.ContentLength
+	}
+	if n < 0 {
+		return errors.New("testrequest: SetContentLength: negative length")
+	}
+	req.ContentLength = n
+	req.Body = &contentLengthReader{ReadCloser: req.Body, remaining: n}
+	if getBody := req.GetBody; getBody != nil {
+		req.GetBody = func() (io.ReadCloser, error) {
+			body, err := getBody()
+			if err != nil {
+				return nil, err
+			}
+			return &contentLengthReader{ReadCloser: body, remaining: n}, nil
+		}
+	}
+	return nil
+}
+
+func (r *contentLengthReader) Read(p []byte) (int, error) {
+	if r.remaining <= 0 {
+		return 0, io.EOF
+	}
+	if int64(len(p)) > r.remaining {
+		p = p[:r.remaining]
+	}
+	n, err := r.ReadCloser.Read(p)
+	r.remaining -= int64(n)
+	if err == io.EOF && r.remaining > 0 {
+		err = io.ErrUnexpectedEOF
+	}
+	return n, err
+}
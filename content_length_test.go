@@ -0,0 +1,107 @@
+package testrequest
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func Test_requestBuilder_SetContentLength(t *testing.T) {
+	tests := []struct {
+		name              string
+		builder           RequestBuilder
+		wantContentLength int64
+		wantBody          string
+		wantReadErr       error
+		wantErr           bool
+	}{
+		{
+			name:              "Default",
+			builder:           Builder().SetBody(strings.NewReader("test")),
+			wantContentLength: 4,
+			wantBody:          "test",
+		},
+		{
+			name:              "Declared",
+			builder:           Builder().SetBody(io.MultiReader(strings.NewReader("test"))).SetContentLength(4),
+			wantContentLength: 4,
+			wantBody:          "test",
+		},
+		{
+			name:              "Unknown",
+			builder:           Builder().SetBody(strings.NewReader("test")).UnknownContentLength(),
+			wantContentLength: -1,
+			wantBody:          "test",
+		},
+		{
+			name:              "Smaller",
+			builder:           Builder().SetBody(strings.NewReader("test")).SetContentLength(2),
+			wantContentLength: 2,
+			wantBody:          "te",
+		},
+		{
+			name:              "Larger",
+			builder:           Builder().SetBody(strings.NewReader("test")).SetContentLength(8),
+			wantContentLength: 8,
+			wantBody:          "test",
+			wantReadErr:       io.ErrUnexpectedEOF,
+		},
+		{
+			name:              "SmallerMismatch",
+			builder:           Builder().SetBody(strings.NewReader("test")).SetContentLengthMismatch(-1),
+			wantContentLength: 3,
+			wantBody:          "tes",
+		},
+		{
+			name:              "LargerMismatch",
+			builder:           Builder().SetBody(strings.NewReader("test")).SetContentLengthMismatch(1),
+			wantContentLength: 5,
+			wantBody:          "test",
+			wantReadErr:       io.ErrUnexpectedEOF,
+		},
+		{
+			name:    "UnknownMismatch",
+			builder: Builder().SetBody(io.MultiReader(strings.NewReader("test"))).SetContentLengthMismatch(1),
+			wantErr: true,
+		},
+		{
+			name:    "Negative",
+			builder: Builder().SetContentLength(-2),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := tt.builder.Build()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SetContentLength() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if req.ContentLength != tt.wantContentLength {
+				t.Errorf("SetContentLength() = %v, want %v", req.ContentLength, tt.wantContentLength)
+			}
+			got, err := io.ReadAll(req.Body)
+			if !errors.Is(err, tt.wantReadErr) {
+				t.Errorf("SetContentLength() readErr = %v, want %v", err, tt.wantReadErr)
+			}
+			if string(got) != tt.wantBody {
+				t.Errorf("SetContentLength() body = %v, want %v", string(got), tt.wantBody)
+			}
+			if req.GetBody == nil {
+				return
+			}
+			body, err := req.GetBody()
+			if err != nil {
+				t.Fatalf("GetBody() error = %v", err)
+			}
+			got, err = io.ReadAll(body)
+			if !errors.Is(err, tt.wantReadErr) || string(got) != tt.wantBody {
+				t.Errorf("GetBody() = %v, %v, want %v, %v", string(got), err, tt.wantBody, tt.wantReadErr)
+			}
+		})
+	}
+}
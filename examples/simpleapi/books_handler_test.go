@@ -40,6 +40,19 @@ func TestBooksHandler_Post(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name:   "InvalidBookRequestEmptyAuthor",
+			fields: fields{},
+			args: args{
+				r: testrequest.Builder().SetJSONFromValue(
+					map[string]interface{}{
+						"title":   "The Go Programming Language",
+						"isbn":    "978-0134190440",
+						"authors": []string{"Alan A. A. Donovan", "Brian W. Kernighan"},
+					}).SetJSONField("authors.1", "").Request(),
+			},
+			wantErr: true,
+		},
 		{
 			name: "FailedAddBookInStore",
 			fields: fields{
@@ -0,0 +1,101 @@
+package testrequest
+
+import (
+	"context"
+	"sync"
+)
+
+type (
+	// A Factory creates RequestBuilders preconfigured with its own defaults.
+	// Unlike SetDefaultTarget, a Factory does not change package state,
+	// so factories of different suites can be used in parallel tests.
+	Factory struct {
+		mu   sync.Mutex
+		base RequestBuilder
+	}
+	// An Option configures the defaults of a Factory.
+	// Any function calling the RequestBuilder's setters can be used as an Option.
+	Option func(b RequestBuilder)
+)
+
+// New returns a new Factory configured with the options.
+// By default the target is https://server.test and HTTP method is set as GET.
+func New(opts ...Option) *Factory {
+	b := newBuilder(baseTarget)
+	for _, opt := range opts {
+		opt(b)
+	}
+	return &Factory{base: b}
+}
+
+// Builder returns a new RequestBuilder with the factory's defaults.
+// It is safe to call Builder from multiple goroutines.
+func (f *Factory) Builder() RequestBuilder {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.base.Clone()
+}
+
+// WithTarget sets the default target.
+func WithTarget(target string) Option {
+	return func(b RequestBuilder) {
+		b.SetTarget(target)
+	}
+}
+
+// WithHeader sets the default header.
+func WithHeader(key string, value ...string) Option {
+	return func(b RequestBuilder) {
+		b.SetHeader(key, value...)
+	}
+}
+
+// WithUserAgent sets the default User-Agent header.
+func WithUserAgent(value string) Option {
+	return func(b RequestBuilder) {
+		b.SetUserAgent(value)
+	}
+}
+
+// WithAccept sets the default Accept header.
+func WithAccept(value string) Option {
+	return func(b RequestBuilder) {
+		b.SetAccept(value)
+	}
+}
+
+// WithAuth sets the default Authorization header.
+// Prefix specifies the authentication scheme.
+func WithAuth(prefix, value string) Option {
+	return func(b RequestBuilder) {
+		b.SetAuth(prefix, value)
+	}
+}
+
+// WithBasicAuth sets the default Authorization header to use HTTP Basic Authentication.
+func WithBasicAuth(username, password string) Option {
+	return func(b RequestBuilder) {
+		b.SetBasicAuth(username, password)
+	}
+}
+
+// WithBearerAuth sets the default Authorization header to use HTTP Bearer Authentication.
+func WithBearerAuth(token string) Option {
+	return func(b RequestBuilder) {
+		b.SetBearerAuth(token)
+	}
+}
+
+// WithContext sets the default request's context.
+func WithContext(ctx context.Context) Option {
+	return func(b RequestBuilder) {
+		b.SetContext(ctx)
+	}
+}
+
+// WithContextValue sets the default request's context value.
+func WithContextValue(key, value interface{}) Option {
+	return func(b RequestBuilder) {
+		b.SetContextValue(key, value)
+	}
+}
@@ -0,0 +1,73 @@
+package testrequest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	f := New(
+		WithTarget("https://books.test"),
+		WithUserAgent("test"),
+		WithAccept("application/json"),
+		WithBearerAuth("token"),
+		WithHeader("X-Test", "test"),
+		WithContextValue("test", "test"),
+	)
+	req := f.Builder().Request()
+	if req.Host != "books.test" {
+		t.Errorf("New() host = %v, want %v", req.Host, "books.test")
+	}
+	if req.Method != http.MethodGet {
+		t.Errorf("New() method = %v, want %v", req.Method, http.MethodGet)
+	}
+	want := http.Header{
+		"User-Agent":    {"test"},
+		"Accept":        {"application/json"},
+		"Authorization": {"bearer token"},
+		"X-Test":        {"test"},
+	}
+	for key := range want {
+		if got := req.Header.Get(key); got != want.Get(key) {
+			t.Errorf("New() header %v = %v, want %v", key, got, want.Get(key))
+		}
+	}
+	if got := req.Context().Value("test"); got != "test" {
+		t.Errorf("New() contextValue = %v, want %v", got, "test")
+	}
+}
+
+func TestNew_Defaults(t *testing.T) {
+	req := New().Builder().Request()
+	if req.Host != "server.test" {
+		t.Errorf("New() host = %v, want %v", req.Host, "server.test")
+	}
+	if req.Context() != context.Background() {
+		t.Errorf("New() context = %v, want %v", req.Context(), context.Background())
+	}
+}
+
+func TestFactory_Builder(t *testing.T) {
+	f := New(WithTarget("https://books.test"), WithHeader("X-Test", "base"))
+	for _, name := range []string{"first", "second", "third"} {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			req := f.Builder().SetHeader("X-Test", name).Request()
+			if got := req.Header.Get("X-Test"); got != name {
+				t.Errorf("Builder() header = %v, want %v", got, name)
+			}
+		})
+	}
+	t.Run("base", func(t *testing.T) {
+		t.Parallel()
+		req := f.Builder().Request()
+		if got := req.Header.Get("X-Test"); got != "base" {
+			t.Errorf("Builder() header = %v, want %v", got, "base")
+		}
+		if req.Host != "books.test" {
+			t.Errorf("Builder() host = %v, want %v", req.Host, "books.test")
+		}
+	})
+}
@@ -0,0 +1,219 @@
+package testrequest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"sync"
+	"time"
+)
+
+type (
+	// A ReadCall records a call of FaultReader.Read.
+	ReadCall struct {
+		// N is the number of bytes read.
+		N int
+		// Err is the returned error.
+		Err error
+	}
+	// A FaultReader is a request body which injects a fault while it is read,
+	// for testing error paths of handlers. It records its Read and Close calls,
+	// so tests can assert how much of the body the handler consumed.
+	//
+	// FaultReaders can wrap each other to combine faults. Use them with RequestBuilder.SetBody.
+	// A FaultReader body is one-shot: Clone, RepeatableRequest and SetContentEncoding
+	// report an error instead of reading it before the request is built.
+	FaultReader struct {
+		r          io.Reader
+		failAfter  int64
+		failErr    error
+		maxRead    int
+		latency    time.Duration
+		blockAfter int64
+		closePanic interface{}
+
+		mu     sync.Mutex
+		ctx    context.Context
+		read   int64
+		reads  []ReadCall
+		closes int
+	}
+)
+
+// errFaultBody is the error of reading a FaultReader body before the request is built.
+var errFaultBody = errors.New("fault bodies are one-shot and cannot be buffered, encoded or cloned")
+
+var faultReaderType = reflect.TypeOf((*FaultReader)(nil))
+
+// isFaultBody reports whether the body is read from a FaultReader.
+func isFaultBody(body bodySource) bool {
+	rb, ok := body.(*readerBody)
+	return ok && len(faultReaders(rb.r)) > 0
+}
+
+// faultReaders returns the FaultReaders of the reader, including the ones wrapped
+// by it, e.g. by io.LimitReader, io.MultiReader or another FaultReader.
+func faultReaders(r io.Reader) []*FaultReader {
+	var found []*FaultReader
+	collectFaultReaders(reflect.ValueOf(r), map[uintptr]bool{}, &found)
+	return found
+}
+
+func collectFaultReaders(v reflect.Value, seen map[uintptr]bool, found *[]*FaultReader) {
+	switch v.Kind() {
+	case reflect.Interface:
+		if !v.IsNil() {
+			collectFaultReaders(v.Elem(), seen, found)
+		}
+	case reflect.Pointer:
+		if v.IsNil() || seen[v.Pointer()] {
+			return
+		}
+		seen[v.Pointer()] = true
+		if v.Type() == faultReaderType {
+			*found = append(*found, (*FaultReader)(v.UnsafePointer()))
+		}
+		collectFaultReaders(v.Elem(), seen, found)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			collectFaultReaders(v.Field(i), seen, found)
+		}
+	case reflect.Slice, reflect.Array:
+		switch v.Type().Elem().Kind() {
+		case reflect.Interface, reflect.Pointer, reflect.Struct:
+			for i := 0; i < v.Len(); i++ {
+				collectFaultReaders(v.Index(i), seen, found)
+			}
+		}
+	}
+}
+
+func newFaultReader(r io.Reader) *FaultReader {
+	return &FaultReader{r: r, failAfter: -1, blockAfter: -1, ctx: context.Background()}
+}
+
+// ErrorAfter returns a FaultReader which reads n bytes of r and then returns err.
+func ErrorAfter(r io.Reader, n int64, err error) *FaultReader {
+	f := newFaultReader(r)
+	f.failAfter, f.failErr = n, err
+	return f
+}
+
+// UnexpectedEOFAfter returns a FaultReader which reads n bytes of r
+// and then returns io.ErrUnexpectedEOF, as if the client disconnected mid-upload.
+func UnexpectedEOFAfter(r io.Reader, n int64) *FaultReader {
+	return ErrorAfter(r, n, io.ErrUnexpectedEOF)
+}
+
+// OneByteReader returns a FaultReader which reads r one byte per Read call.
+func OneByteReader(r io.Reader) *FaultReader {
+	f := newFaultReader(r)
+	f.maxRead = 1
+	return f
+}
+
+// SlowReader returns a FaultReader which reads r with the latency added to each Read call.
+func SlowReader(r io.Reader, latency time.Duration) *FaultReader {
+	f := newFaultReader(r)
+	f.latency = latency
+	return f
+}
+
+// BlockAfter returns a FaultReader which reads n bytes of r and then blocks
+// until the context of the built request is done, returning the context's error.
+// The context must be set by RequestBuilder.SetContext and be cancellable,
+// otherwise Build returns an error.
+func BlockAfter(r io.Reader, n int64) *FaultReader {
+	f := newFaultReader(r)
+	f.blockAfter = n
+	return f
+}
+
+// PanicOnClose returns a FaultReader which reads r and panics with the value when it is closed.
+func PanicOnClose(r io.Reader, v interface{}) *FaultReader {
+	f := newFaultReader(r)
+	f.closePanic = v
+	return f
+}
+
+func (f *FaultReader) Read(p []byte) (n int, err error) {
+	defer func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.read += int64(n)
+		f.reads = append(f.reads, ReadCall{N: n, Err: err})
+	}()
+	if f.latency > 0 {
+		time.Sleep(f.latency)
+	}
+	if f.maxRead > 0 && len(p) > f.maxRead {
+		p = p[:f.maxRead]
+	}
+	f.mu.Lock()
+	read, ctx := f.read, f.ctx
+	f.mu.Unlock()
+	if f.failAfter >= 0 {
+		if read >= f.failAfter {
+			return 0, f.failErr
+		}
+		p = limit(p, f.failAfter-read)
+	}
+	if f.blockAfter >= 0 {
+		if read >= f.blockAfter {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		}
+		p = limit(p, f.blockAfter-read)
+	}
+	return f.r.Read(p)
+}
+
+// Close closes the underlying reader if it is an io.Closer.
+func (f *FaultReader) Close() error {
+	f.mu.Lock()
+	f.closes++
+	f.mu.Unlock()
+	if f.closePanic != nil {
+		panic(f.closePanic)
+	}
+	if c, ok := f.r.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// Reads returns the recorded Read calls.
+func (f *FaultReader) Reads() []ReadCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]ReadCall(nil), f.reads...)
+}
+
+// BytesRead returns the number of bytes read.
+func (f *FaultReader) BytesRead() int64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.read
+}
+
+// CloseCalls returns the number of Close calls.
+func (f *FaultReader) CloseCalls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.closes
+}
+
+func (f *FaultReader) bindContext(ctx context.Context) {
+	f.mu.Lock()
+	f.ctx = ctx
+	f.mu.Unlock()
+}
+
+// limit returns p limited to n bytes.
+func limit(p []byte, n int64) []byte {
+	if int64(len(p)) > n {
+		return p[:n]
+	}
+	return p
+}
@@ -0,0 +1,199 @@
+package testrequest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestErrorAfter(t *testing.T) {
+	wantErr := errors.New("connection reset")
+	body := ErrorAfter(strings.NewReader("test body"), 4, wantErr)
+	req := Builder().SetBody(body).Request()
+	got, err := io.ReadAll(req.Body)
+	if !errors.Is(err, wantErr) || string(got) != "test" {
+		t.Errorf("ErrorAfter() = %q, %v, want %q, %v", string(got), err, "test", wantErr)
+	}
+	if body.BytesRead() != 4 {
+		t.Errorf("ErrorAfter() bytesRead = %v, want %v", body.BytesRead(), 4)
+	}
+	reads := body.Reads()
+	if last := reads[len(reads)-1]; last.N != 0 || !errors.Is(last.Err, wantErr) {
+		t.Errorf("ErrorAfter() last read = %v, want error %v", last, wantErr)
+	}
+}
+
+func TestUnexpectedEOFAfter(t *testing.T) {
+	req := Builder().SetBody(UnexpectedEOFAfter(strings.NewReader("test body"), 2)).Request()
+	got, err := io.ReadAll(req.Body)
+	if !errors.Is(err, io.ErrUnexpectedEOF) || string(got) != "te" {
+		t.Errorf("UnexpectedEOFAfter() = %q, %v, want %q, %v", string(got), err, "te", io.ErrUnexpectedEOF)
+	}
+}
+
+func TestOneByteReader(t *testing.T) {
+	body := OneByteReader(strings.NewReader("test"))
+	req := Builder().SetBody(body).Request()
+	got, _ := io.ReadAll(req.Body)
+	if string(got) != "test" {
+		t.Errorf("OneByteReader() = %q, want %q", string(got), "test")
+	}
+	for _, call := range body.Reads() {
+		if call.N > 1 {
+			t.Errorf("OneByteReader() read = %v, want at most 1 byte", call.N)
+		}
+	}
+	if len(body.Reads()) != 5 {
+		t.Errorf("OneByteReader() reads = %v, want %v", len(body.Reads()), 5)
+	}
+}
+
+func TestSlowReader(t *testing.T) {
+	latency := 10 * time.Millisecond
+	body := SlowReader(OneByteReader(strings.NewReader("te")), latency)
+	req := Builder().SetBody(body).Request()
+	start := time.Now()
+	io.ReadAll(req.Body)
+	if d := time.Since(start); d < 3*latency {
+		t.Errorf("SlowReader() duration = %v, want at least %v", d, 3*latency)
+	}
+}
+
+func TestBlockAfter(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	body := BlockAfter(strings.NewReader("test body"), 4)
+	req := Builder().SetContext(ctx).SetBody(body).Request()
+	done := make(chan error)
+	go func() {
+		_, err := io.ReadAll(req.Body)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		t.Errorf("BlockAfter() returned before cancel, err = %v", err)
+		return
+	case <-time.After(20 * time.Millisecond):
+	}
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("BlockAfter() error = %v, want %v", err, context.Canceled)
+	}
+	if body.BytesRead() != 4 {
+		t.Errorf("BlockAfter() bytesRead = %v, want %v", body.BytesRead(), 4)
+	}
+}
+
+func TestPanicOnClose(t *testing.T) {
+	body := PanicOnClose(strings.NewReader("test"), "close")
+	req := Builder().SetBody(body).Request()
+	defer func() {
+		if r := recover(); r != "close" {
+			t.Errorf("PanicOnClose() recovered = %v, want %v", r, "close")
+		}
+		if body.CloseCalls() != 1 {
+			t.Errorf("PanicOnClose() closeCalls = %v, want %v", body.CloseCalls(), 1)
+		}
+	}()
+	req.Body.Close()
+}
+
+func TestFaultReader_OneShot(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(b RequestBuilder) error
+	}{
+		{
+			name: "Clone",
+			build: func(b RequestBuilder) error {
+				_, err := b.Clone().Build()
+				return err
+			},
+		},
+		{
+			name: "RepeatableRequest",
+			build: func(b RequestBuilder) (err error) {
+				defer func() {
+					if r := recover(); r != nil {
+						err = r.(error)
+					}
+				}()
+				b.RepeatableRequest()
+				return nil
+			},
+		},
+		{
+			name: "SetContentEncoding",
+			build: func(b RequestBuilder) error {
+				_, err := b.SetContentEncoding("gzip").Build()
+				return err
+			},
+		},
+	}
+	wrappers := []struct {
+		name string
+		wrap func(r io.Reader) io.Reader
+	}{
+		{name: "FaultReader", wrap: func(r io.Reader) io.Reader { return r }},
+		{name: "LimitReader", wrap: func(r io.Reader) io.Reader { return io.LimitReader(r, 100) }},
+		{name: "MultiReader", wrap: func(r io.Reader) io.Reader { return io.MultiReader(strings.NewReader("a"), r) }},
+	}
+	for _, tt := range tests {
+		for _, w := range wrappers {
+			t.Run(tt.name+"/"+w.name, func(t *testing.T) {
+				body := BlockAfter(strings.NewReader("test body"), 1)
+				done := make(chan error, 1)
+				go func() {
+					done <- tt.build(Builder().SetBody(w.wrap(body)))
+				}()
+				select {
+				case err := <-done:
+					if !errors.Is(err, errFaultBody) {
+						t.Errorf("%s() error = %v, want %v", tt.name, err, errFaultBody)
+					}
+				case <-time.After(time.Second):
+					t.Fatalf("%s() blocked reading the fault body", tt.name)
+				}
+				if body.BytesRead() != 0 {
+					t.Errorf("BytesRead() = %v, want %v", body.BytesRead(), 0)
+				}
+			})
+		}
+	}
+}
+
+func TestFaultReader_CloneParent(t *testing.T) {
+	wantErr := errors.New("connection reset")
+	base := Builder().SetBody(ErrorAfter(strings.NewReader("test body"), 4, wantErr))
+	if _, err := base.Clone().Build(); !errors.Is(err, errFaultBody) {
+		t.Errorf("Clone() error = %v, want %v", err, errFaultBody)
+	}
+	req, err := base.Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	got, err := io.ReadAll(req.Body)
+	if !errors.Is(err, wantErr) || string(got) != "test" {
+		t.Errorf("Build() body = %v, %v, want %v, %v", string(got), err, "test", wantErr)
+	}
+}
+
+func TestBlockAfter_Wrapped(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	body := BlockAfter(strings.NewReader("test body"), 4)
+	req := Builder().SetContext(ctx).SetBody(io.LimitReader(body, 100)).Request()
+	cancel()
+	if _, err := io.ReadAll(req.Body); !errors.Is(err, context.Canceled) {
+		t.Errorf("BlockAfter() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestBlockAfter_WithoutContext(t *testing.T) {
+	body := BlockAfter(strings.NewReader("test body"), 4)
+	if _, err := Builder().SetBody(body).Build(); err == nil {
+		t.Errorf("BlockAfter() error = %v, wantErr %v", err, true)
+	}
+}
@@ -0,0 +1,36 @@
+package testrequest
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"text/template"
+)
+
+func (b *requestBuilder) SetBodyFromFile(name string) RequestBuilder {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return b.addError("SetBodyFromFile", err)
+	}
+	return b.setPayload(data, typeByExtension(name))
+}
+
+func (b *requestBuilder) SetBodyFromFS(fsys fs.FS, name string) RequestBuilder {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return b.addError("SetBodyFromFS", err)
+	}
+	return b.setPayload(data, typeByExtension(name))
+}
+
+func (b *requestBuilder) SetBodyTemplate(tmpl string, data interface{}) RequestBuilder {
+	t, err := template.New("body").Parse(tmpl)
+	if err != nil {
+		return b.addError("SetBodyTemplate", err)
+	}
+	var buf bytes.Buffer
+	if err = t.Execute(&buf, data); err != nil {
+		return b.addError("SetBodyTemplate", err)
+	}
+	return b.setPayload(buf.Bytes(), "")
+}
@@ -0,0 +1,93 @@
+package testrequest
+
+import (
+	"io"
+	"net/http"
+	"testing"
+	"testing/fstest"
+)
+
+func Test_requestBuilder_SetBodyFromFile(t *testing.T) {
+	tests := []struct {
+		name            string
+		builder         RequestBuilder
+		wantMethod      string
+		wantContentType string
+		wantBody        string
+		wantErr         bool
+	}{
+		{
+			name:            "File",
+			builder:         Builder().SetBodyFromFile("testdata/book.json"),
+			wantMethod:      http.MethodPost,
+			wantContentType: "application/json",
+			wantBody:        `{"title": "The Go Programming Language", "isbn": "978-0134190440"}` + "\n",
+		},
+		{
+			name:            "UnknownExtension",
+			builder:         Builder().SetMethod(http.MethodPut).SetBodyFromFS(fstest.MapFS{"book.bin": {Data: []byte("test")}}, "book.bin"),
+			wantMethod:      http.MethodPut,
+			wantContentType: "application/octet-stream",
+			wantBody:        "test",
+		},
+		{
+			name:            "FS",
+			builder:         Builder().SetBodyFromFS(fstest.MapFS{"books/book.json": {Data: []byte("{}")}}, "books/book.json"),
+			wantMethod:      http.MethodPost,
+			wantContentType: "application/json",
+			wantBody:        "{}",
+		},
+		{
+			name:    "MissingFile",
+			builder: Builder().SetBodyFromFile("testdata/missing.json"),
+			wantErr: true,
+		},
+		{
+			name:    "MissingFSFile",
+			builder: Builder().SetBodyFromFS(fstest.MapFS{}, "missing.json"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := tt.builder.Build()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SetBodyFromFile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if req.Method != tt.wantMethod {
+				t.Errorf("SetBodyFromFile() method = %v, want %v", req.Method, tt.wantMethod)
+			}
+			if got := req.Header.Get("Content-Type"); got != tt.wantContentType {
+				t.Errorf("SetBodyFromFile() contentType = %v, want %v", got, tt.wantContentType)
+			}
+			got, _ := io.ReadAll(req.Body)
+			if string(got) != tt.wantBody {
+				t.Errorf("SetBodyFromFile() = %v, want %v", string(got), tt.wantBody)
+			}
+		})
+	}
+}
+
+func Test_requestBuilder_SetBodyTemplate(t *testing.T) {
+	req := Builder().
+		SetContentType("application/json").
+		SetBodyTemplate(`{"title": {{printf "%q" .Title}}}`, struct{ Title string }{Title: "Go"}).
+		Request()
+	got, _ := io.ReadAll(req.Body)
+	if string(got) != `{"title": "Go"}` {
+		t.Errorf("SetBodyTemplate() = %v, want %v", string(got), `{"title": "Go"}`)
+	}
+	if req.Method != http.MethodPost {
+		t.Errorf("SetBodyTemplate() method = %v, want %v", req.Method, http.MethodPost)
+	}
+	if _, err := Builder().SetBodyTemplate("{{.Title", nil).Build(); err == nil {
+		t.Errorf("SetBodyTemplate() parse error = %v, wantErr %v", err, true)
+	}
+	if _, err := Builder().SetBodyTemplate("{{.Title.Missing}}", struct{ Title string }{}).Build(); err == nil {
+		t.Errorf("SetBodyTemplate() execute error = %v, wantErr %v", err, true)
+	}
+}
@@ -0,0 +1,211 @@
+package testrequest
+
+import (
+	"encoding"
+	"fmt"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	// A FormOption configures the encoding of a struct as form values.
+	FormOption  func(o *formOptions)
+	formOptions struct {
+		brackets   bool
+		timeLayout string
+	}
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// WithBracketNotation encodes the fields of nested structs and maps as parent[field]
+// and the elements of struct slices as parent[0][field], instead of parent.field and parent.0.field.
+func WithBracketNotation() FormOption {
+	return func(o *formOptions) {
+		o.brackets = true
+	}
+}
+
+// WithTimeLayout sets the layout of time.Time values. By default it is time.RFC3339.
+func WithTimeLayout(layout string) FormOption {
+	return func(o *formOptions) {
+		o.timeLayout = layout
+	}
+}
+
+func (b *requestBuilder) SetPostFormFromValue(v interface{}, opts ...FormOption) RequestBuilder {
+	values, err := encodeForm(v, opts...)
+	if err != nil {
+		return b.addError("SetPostFormFromValue", err)
+	}
+	return b.SetPostForm(values)
+}
+
+func (b *requestBuilder) SetQueryFromValue(v interface{}, opts ...FormOption) RequestBuilder {
+	values, err := encodeForm(v, opts...)
+	if err != nil {
+		return b.addError("SetQueryFromValue", err)
+	}
+	return b.SetQuery(values)
+}
+
+// encodeForm encodes the struct as form values.
+func encodeForm(v interface{}, opts ...FormOption) (url.Values, error) {
+	o := &formOptions{timeLayout: time.RFC3339}
+	for _, opt := range opts {
+		opt(o)
+	}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("unsupported form value type %T", v)
+	}
+	values := url.Values{}
+	if err := o.encodeStruct(values, "", rv); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// key returns the key of the field nested in the prefix.
+func (o *formOptions) key(prefix, name string) string {
+	switch {
+	case prefix == "":
+		return name
+	case o.brackets:
+		return prefix + "[" + name + "]"
+	}
+	return prefix + "." + name
+}
+
+func (o *formOptions) encodeStruct(values url.Values, prefix string, v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		embedded := field.Anonymous && indirectType(field.Type).Kind() == reflect.Struct && indirectType(field.Type) != timeType
+		if !field.IsExported() && !embedded {
+			continue
+		}
+		tag, ok := field.Tag.Lookup("form")
+		if !ok {
+			tag = field.Tag.Get("url")
+		}
+		if tag == "-" {
+			continue
+		}
+		name, flags, _ := strings.Cut(tag, ",")
+		fv := v.Field(i)
+		if slices.Contains(strings.Split(flags, ","), "omitempty") && isEmptyFormValue(fv) {
+			continue
+		}
+		if embedded && (name == "" || !field.IsExported()) {
+			for fv.Kind() == reflect.Pointer && !fv.IsNil() {
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct {
+				if err := o.encodeStruct(values, prefix, fv); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if err := o.encodeValue(values, o.key(prefix, name), fv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *formOptions) encodeValue(values url.Values, key string, v reflect.Value) error {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			values.Add(key, "")
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Type() == timeType {
+		values.Add(key, v.Interface().(time.Time).Format(o.timeLayout))
+		return nil
+	}
+	if m, ok := v.Interface().(encoding.TextMarshaler); ok {
+		text, err := m.MarshalText()
+		if err != nil {
+			return fmt.Errorf("form field %s: %w", key, err)
+		}
+		values.Add(key, string(text))
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		return o.encodeStruct(values, key, v)
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			break
+		}
+		iter := v.MapRange()
+		for iter.Next() {
+			if err := o.encodeValue(values, o.key(key, iter.Key().String()), iter.Value()); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			values.Add(key, string(v.Bytes()))
+			return nil
+		}
+		nested := indirectType(v.Type().Elem())
+		for i := 0; i < v.Len(); i++ {
+			k := key
+			if nested.Kind() == reflect.Struct && nested != timeType || nested.Kind() == reflect.Map {
+				k = o.key(key, strconv.Itoa(i))
+			}
+			if err := o.encodeValue(values, k, v.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.String:
+		values.Add(key, v.String())
+		return nil
+	case reflect.Bool:
+		values.Add(key, strconv.FormatBool(v.Bool()))
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		values.Add(key, strconv.FormatInt(v.Int(), 10))
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		values.Add(key, strconv.FormatUint(v.Uint(), 10))
+		return nil
+	case reflect.Float32, reflect.Float64:
+		values.Add(key, strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits()))
+		return nil
+	}
+	return fmt.Errorf("form field %s: unsupported type %s", key, v.Type())
+}
+
+// isEmptyFormValue reports whether the value is omitted by the omitempty option.
+func isEmptyFormValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Array, reflect.String:
+		return v.Len() == 0
+	}
+	return v.IsZero()
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
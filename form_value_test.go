@@ -0,0 +1,147 @@
+package testrequest
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type formAddress struct {
+	City string `form:"city"`
+	Zip  string `url:"zip,omitempty"`
+}
+
+type formPage struct {
+	Page int `url:"page"`
+}
+
+type formUser struct {
+	formPage
+	Name      string            `form:"name"`
+	Nickname  *string           `form:"nickname"`
+	Age       *int              `form:"age,omitempty"`
+	Tags      []string          `form:"tags"`
+	Address   formAddress       `form:"address"`
+	Previous  []formAddress     `form:"previous,omitempty"`
+	Labels    map[string]string `form:"labels,omitempty"`
+	Birthday  time.Time         `form:"birthday"`
+	IP        net.IP            `form:"ip,omitempty"`
+	Admin     bool
+	Score     float64   `form:"score"`
+	Secret    string    `form:"-"`
+	CreatedAt time.Time `form:"created_at,omitempty"`
+	private   string
+}
+
+func Test_encodeForm(t *testing.T) {
+	age := 30
+	user := formUser{
+		formPage: formPage{Page: 2},
+		Name:     "Gopher",
+		Age:      &age,
+		Tags:     []string{"go", "http"},
+		Address:  formAddress{City: "Berlin"},
+		Previous: []formAddress{{City: "Paris", Zip: "75001"}},
+		Labels:   map[string]string{"team": "core"},
+		Birthday: time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC),
+		IP:       net.ParseIP("192.0.2.1"),
+		Score:    0.5,
+		Secret:   "secret",
+		private:  "private",
+	}
+	tests := []struct {
+		name    string
+		v       interface{}
+		opts    []FormOption
+		want    url.Values
+		wantErr bool
+	}{
+		{
+			name: "DotNotation",
+			v:    &user,
+			want: url.Values{
+				"page":            {"2"},
+				"name":            {"Gopher"},
+				"nickname":        {""},
+				"age":             {"30"},
+				"tags":            {"go", "http"},
+				"address.city":    {"Berlin"},
+				"previous.0.city": {"Paris"},
+				"previous.0.zip":  {"75001"},
+				"labels.team":     {"core"},
+				"birthday":        {"2009-11-10T23:00:00Z"},
+				"ip":              {"192.0.2.1"},
+				"Admin":           {"false"},
+				"score":           {"0.5"},
+			},
+		},
+		{
+			name: "BracketNotation",
+			v:    formUser{Address: formAddress{City: "Berlin", Zip: "10115"}, Previous: []formAddress{{City: "Paris"}}},
+			opts: []FormOption{WithBracketNotation(), WithTimeLayout(time.DateOnly)},
+			want: url.Values{
+				"page":              {"0"},
+				"name":              {""},
+				"nickname":          {""},
+				"address[city]":     {"Berlin"},
+				"address[zip]":      {"10115"},
+				"previous[0][city]": {"Paris"},
+				"birthday":          {"0001-01-01"},
+				"Admin":             {"false"},
+				"score":             {"0"},
+			},
+		},
+		{
+			name:    "NotStruct",
+			v:       map[string]string{"name": "Gopher"},
+			wantErr: true,
+		},
+		{
+			name: "UnsupportedField",
+			v: struct {
+				C chan int `form:"c"`
+			}{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := encodeForm(tt.v, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("encodeForm() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("encodeForm() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_requestBuilder_SetPostFormFromValue(t *testing.T) {
+	req := Builder().SetPostFormFromValue(formAddress{City: "Berlin"}).Request()
+	if req.Method != http.MethodPost {
+		t.Errorf("SetPostFormFromValue() method = %v, want %v", req.Method, http.MethodPost)
+	}
+	if err := req.ParseForm(); err != nil {
+		t.Fatalf("ParseForm() error = %v", err)
+	}
+	if got := req.PostForm.Encode(); got != "city=Berlin" {
+		t.Errorf("SetPostFormFromValue() = %v, want %v", got, "city=Berlin")
+	}
+	if _, err := Builder().SetPostFormFromValue("city").Build(); err == nil {
+		t.Errorf("SetPostFormFromValue() error = %v, wantErr %v", err, true)
+	}
+}
+
+func Test_requestBuilder_SetQueryFromValue(t *testing.T) {
+	req := Builder().SetTarget("/books?sort=title").SetQueryFromValue(formPage{Page: 3}).Request()
+	if got := req.URL.RawQuery; got != "page=3" {
+		t.Errorf("SetQueryFromValue() = %v, want %v", got, "page=3")
+	}
+	if _, err := Builder().SetQueryFromValue(nil).Build(); err == nil {
+		t.Errorf("SetQueryFromValue() error = %v, wantErr %v", err, true)
+	}
+}
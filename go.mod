@@ -1,3 +1,3 @@
 module github.com/redagain/go-testrequest
 
-go 1.16
+go 1.22
@@ -0,0 +1,219 @@
+package testrequest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// PatchOp is an operation of a JSON Patch document, see RFC 6902.
+type PatchOp struct {
+	// Op is the operation: add, remove, replace, move, copy or test.
+	Op string
+	// Path is the JSON Pointer to the target location.
+	Path string
+	// From is the JSON Pointer to the source location of move and copy.
+	From string
+	// Value is the value of add, replace and test.
+	Value interface{}
+}
+
+// MarshalJSON encodes the operation with the members required by its Op.
+func (op PatchOp) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{"op": op.Op, "path": op.Path}
+	switch op.Op {
+	case "add", "replace", "test":
+		m["value"] = op.Value
+	case "move", "copy":
+		m["from"] = op.From
+	}
+	return json.Marshal(m)
+}
+
+// PatchAdd returns the add operation.
+func PatchAdd(path string, value interface{}) PatchOp {
+	return PatchOp{Op: "add", Path: path, Value: value}
+}
+
+// PatchRemove returns the remove operation.
+func PatchRemove(path string) PatchOp {
+	return PatchOp{Op: "remove", Path: path}
+}
+
+// PatchReplace returns the replace operation.
+func PatchReplace(path string, value interface{}) PatchOp {
+	return PatchOp{Op: "replace", Path: path, Value: value}
+}
+
+// PatchMove returns the move operation.
+func PatchMove(from, path string) PatchOp {
+	return PatchOp{Op: "move", Path: path, From: from}
+}
+
+// PatchCopy returns the copy operation.
+func PatchCopy(from, path string) PatchOp {
+	return PatchOp{Op: "copy", Path: path, From: from}
+}
+
+// PatchTest returns the test operation.
+func PatchTest(path string, value interface{}) PatchOp {
+	return PatchOp{Op: "test", Path: path, Value: value}
+}
+
+func (b *requestBuilder) SetJSONPatch(ops ...PatchOp) RequestBuilder {
+	if ops == nil {
+		ops = []PatchOp{}
+	}
+	data, err := json.Marshal(ops)
+	if err != nil {
+		return b.addError("SetJSONPatch", err)
+	}
+	return b.setPatch(data, "application/json-patch+json")
+}
+
+func (b *requestBuilder) SetMergePatch(v interface{}) RequestBuilder {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return b.addError("SetMergePatch", err)
+	}
+	return b.setPatch(data, "application/merge-patch+json")
+}
+
+// setPatch sets the patch document to the request body like setPayload, but with PATCH method.
+func (b *requestBuilder) setPatch(data []byte, contentType string) RequestBuilder {
+	if b.method == http.MethodGet || b.method == http.MethodDelete {
+		b.method = http.MethodPatch
+	}
+	return b.setPayload(data, contentType)
+}
+
+// JSONPatchDiff returns the JSON Patch operations that change the JSON encoding of from
+// into the JSON encoding of to, for SetJSONPatch.
+func JSONPatchDiff(from, to interface{}) ([]PatchOp, error) {
+	a, b, err := normalizeJSON(from, to)
+	if err != nil {
+		return nil, err
+	}
+	ops := []PatchOp{}
+	diffJSONPatch("", a, b, &ops)
+	return ops, nil
+}
+
+// MergePatchDiff returns the JSON Merge Patch, see RFC 7396, that changes the JSON encoding
+// of from into the JSON encoding of to, for SetMergePatch. Since null removes a member
+// in a merge patch, an error is returned if a member of to, at any depth, is changed to null.
+func MergePatchDiff(from, to interface{}) (json.RawMessage, error) {
+	a, b, err := normalizeJSON(from, to)
+	if err != nil {
+		return nil, err
+	}
+	patch, err := diffMergePatch("", a, b)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(patch)
+}
+
+// normalizeJSON converts the values to generic values of their JSON encoding.
+func normalizeJSON(from, to interface{}) (a, b interface{}, err error) {
+	if a, err = toGenericJSON(from); err == nil {
+		b, err = toGenericJSON(to)
+	}
+	if err != nil {
+		err = fmt.Errorf("testrequest: %w", err)
+	}
+	return
+}
+
+func diffJSONPatch(path string, a, b interface{}, ops *[]PatchOp) {
+	switch a := a.(type) {
+	case map[string]interface{}:
+		b, ok := b.(map[string]interface{})
+		if !ok {
+			break
+		}
+		for _, k := range sortedKeys(a) {
+			if _, ok := b[k]; !ok {
+				*ops = append(*ops, PatchRemove(path+"/"+pointerEscaper.Replace(k)))
+			}
+		}
+		for _, k := range sortedKeys(b) {
+			p := path + "/" + pointerEscaper.Replace(k)
+			if v, ok := a[k]; ok {
+				diffJSONPatch(p, v, b[k], ops)
+			} else {
+				*ops = append(*ops, PatchAdd(p, b[k]))
+			}
+		}
+		return
+	case []interface{}:
+		b, ok := b.([]interface{})
+		if !ok {
+			break
+		}
+		n := len(a)
+		if len(b) < n {
+			n = len(b)
+		}
+		for i := 0; i < n; i++ {
+			diffJSONPatch(path+"/"+strconv.Itoa(i), a[i], b[i], ops)
+		}
+		for i := len(a) - 1; i >= n; i-- {
+			*ops = append(*ops, PatchRemove(path+"/"+strconv.Itoa(i)))
+		}
+		for i := n; i < len(b); i++ {
+			*ops = append(*ops, PatchAdd(path+"/"+strconv.Itoa(i), b[i]))
+		}
+		return
+	}
+	if !reflect.DeepEqual(a, b) {
+		*ops = append(*ops, PatchReplace(path, b))
+	}
+}
+
+func diffMergePatch(path string, a, b interface{}) (interface{}, error) {
+	am, ok := a.(map[string]interface{})
+	bm, ok2 := b.(map[string]interface{})
+	if !ok2 {
+		return b, nil
+	}
+	if !ok {
+		// The object replaces a non-object and is merged into an empty object,
+		// so none of its members, however deep, can be null.
+		am = map[string]interface{}{}
+	}
+	patch := map[string]interface{}{}
+	for k := range am {
+		if _, ok := bm[k]; !ok {
+			patch[k] = nil
+		}
+	}
+	for _, k := range sortedKeys(bm) {
+		v := bm[k]
+		old, ok := am[k]
+		if ok && reflect.DeepEqual(old, v) {
+			continue
+		}
+		if v == nil {
+			return nil, fmt.Errorf("testrequest: MergePatchDiff: %s/%s is changed to null", path, pointerEscaper.Replace(k))
+		}
+		p, err := diffMergePatch(path+"/"+pointerEscaper.Replace(k), old, v)
+		if err != nil {
+			return nil, err
+		}
+		patch[k] = p
+	}
+	return patch, nil
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
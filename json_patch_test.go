@@ -0,0 +1,165 @@
+package testrequest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func Test_requestBuilder_SetJSONPatch(t *testing.T) {
+	req := Builder().SetJSONPatch(
+		PatchTest("/title", "Go"),
+		PatchReplace("/title", "The Go Programming Language"),
+		PatchAdd("/authors/-", "Brian W. Kernighan"),
+		PatchRemove("/price"),
+		PatchMove("/isbn", "/isbn13"),
+		PatchCopy("/title", "/subtitle"),
+		PatchAdd("/note", nil),
+	).Request()
+	if req.Method != http.MethodPatch {
+		t.Errorf("SetJSONPatch() method = %v, want %v", req.Method, http.MethodPatch)
+	}
+	if got := req.Header.Get("Content-Type"); got != "application/json-patch+json" {
+		t.Errorf("SetJSONPatch() contentType = %v, want %v", got, "application/json-patch+json")
+	}
+	got, _ := io.ReadAll(req.Body)
+	want := `[{"op":"test","path":"/title","value":"Go"},` +
+		`{"op":"replace","path":"/title","value":"The Go Programming Language"},` +
+		`{"op":"add","path":"/authors/-","value":"Brian W. Kernighan"},` +
+		`{"op":"remove","path":"/price"},` +
+		`{"from":"/isbn","op":"move","path":"/isbn13"},` +
+		`{"from":"/title","op":"copy","path":"/subtitle"},` +
+		`{"op":"add","path":"/note","value":null}]`
+	if string(got) != want {
+		t.Errorf("SetJSONPatch() body = %v, want %v", string(got), want)
+	}
+	got, _ = io.ReadAll(Builder().SetJSONPatch().Request().Body)
+	if string(got) != "[]" {
+		t.Errorf("SetJSONPatch() empty body = %v, want %v", string(got), "[]")
+	}
+}
+
+func Test_requestBuilder_SetMergePatch(t *testing.T) {
+	req := Builder().SetMethod(http.MethodPut).SetMergePatch(map[string]interface{}{"title": nil}).Request()
+	if req.Method != http.MethodPut {
+		t.Errorf("SetMergePatch() method = %v, want %v", req.Method, http.MethodPut)
+	}
+	if got := req.Header.Get("Content-Type"); got != "application/merge-patch+json" {
+		t.Errorf("SetMergePatch() contentType = %v, want %v", got, "application/merge-patch+json")
+	}
+	got, _ := io.ReadAll(req.Body)
+	if want := `{"title":null}`; string(got) != want {
+		t.Errorf("SetMergePatch() body = %v, want %v", string(got), want)
+	}
+	if _, err := Builder().SetMergePatch(make(chan int)).Build(); err == nil {
+		t.Errorf("SetMergePatch() error = %v, wantErr %v", err, true)
+	}
+}
+
+var patchDiffTests = []struct {
+	name     string
+	from, to interface{}
+}{
+	{
+		name: "Objects",
+		from: map[string]interface{}{"title": "Go", "price": 44.99, "a/b": 1, "meta": map[string]interface{}{"pages": 380}},
+		to:   map[string]interface{}{"title": "The Go Programming Language", "isbn": "978-0134190440", "a/b": 2, "meta": map[string]interface{}{}},
+	},
+	{
+		name: "Arrays",
+		from: map[string]interface{}{"authors": []string{"Alan A. A. Donovan", "Brian W. Kernighan", "Rob Pike"}},
+		to:   map[string]interface{}{"authors": []string{"Alan A. A. Donovan"}, "tags": []interface{}{map[string]interface{}{"name": "go"}}},
+	},
+	{
+		name: "Root",
+		from: []int{1},
+		to:   "go",
+	},
+	{
+		name: "Equal",
+		from: xmlBook{Title: "Go"},
+		to:   xmlBook{Title: "Go"},
+	},
+}
+
+func TestJSONPatchDiff(t *testing.T) {
+	for _, tt := range patchDiffTests {
+		t.Run(tt.name, func(t *testing.T) {
+			ops, err := JSONPatchDiff(tt.from, tt.to)
+			if err != nil {
+				t.Fatalf("JSONPatchDiff() error = %v", err)
+			}
+			doc, want, _ := normalizeJSON(tt.from, tt.to)
+			for _, op := range ops {
+				switch op.Op {
+				case "remove":
+					doc, err = deleteJSONValue(doc, parseJSONPath(op.Path))
+				default:
+					doc, err = setJSONValue(doc, parseJSONPath(op.Path), op.Value)
+				}
+				if err != nil {
+					t.Fatalf("JSONPatchDiff() op %+v error = %v", op, err)
+				}
+			}
+			if !reflect.DeepEqual(doc, want) {
+				t.Errorf("JSONPatchDiff() patched = %v, want %v", doc, want)
+			}
+		})
+	}
+}
+
+func TestMergePatchDiff(t *testing.T) {
+	for _, tt := range patchDiffTests {
+		t.Run(tt.name, func(t *testing.T) {
+			patch, err := MergePatchDiff(tt.from, tt.to)
+			if err != nil {
+				t.Fatalf("MergePatchDiff() error = %v", err)
+			}
+			doc, want, _ := normalizeJSON(tt.from, tt.to)
+			p, _ := decodeJSON(patch)
+			if doc = mergePatch(doc, p); !reflect.DeepEqual(doc, want) {
+				t.Errorf("MergePatchDiff() patched = %v, want %v", doc, want)
+			}
+		})
+	}
+	nulls := []struct{ from, to interface{} }{
+		{map[string]interface{}{"title": "Go"}, map[string]interface{}{"title": nil}},
+		{map[string]interface{}{"a": 1}, map[string]interface{}{"a": map[string]interface{}{"b": nil}}},
+		{map[string]interface{}{}, map[string]interface{}{"a": map[string]interface{}{"b": map[string]interface{}{"c": nil}}}},
+		{"go", map[string]interface{}{"a": nil}},
+	}
+	for _, tt := range nulls {
+		if _, err := MergePatchDiff(tt.from, tt.to); err == nil {
+			t.Errorf("MergePatchDiff(%v, %v) error = %v, wantErr %v", tt.from, tt.to, err, true)
+		}
+	}
+}
+
+// mergePatch applies the merge patch to the target, see RFC 7396.
+func mergePatch(target, patch interface{}) interface{} {
+	p, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]interface{})
+	if !ok {
+		t = map[string]interface{}{}
+	}
+	for k, v := range p {
+		if v == nil {
+			delete(t, k)
+		} else {
+			t[k] = mergePatch(t[k], v)
+		}
+	}
+	return t
+}
+
+func TestPatchOp_MarshalJSON(t *testing.T) {
+	got, err := json.Marshal(PatchOp{Op: "remove", Path: "/a", From: "/b", Value: 1})
+	if err != nil || string(got) != `{"op":"remove","path":"/a"}` {
+		t.Errorf("MarshalJSON() = %v, %v, want %v", string(got), err, `{"op":"remove","path":"/a"}`)
+	}
+}
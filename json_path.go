@@ -0,0 +1,210 @@
+package testrequest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// jsonEdit is a change of the JSON body made by SetJSONField or DeleteJSONField.
+type jsonEdit struct {
+	path   string
+	value  interface{}
+	delete bool
+}
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+var pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+
+func (b *requestBuilder) SetJSONField(path string, value interface{}) RequestBuilder {
+	b.jsonEdits = append(b.jsonEdits, jsonEdit{path: path, value: value})
+	return b
+}
+
+func (b *requestBuilder) DeleteJSONField(path string) RequestBuilder {
+	b.jsonEdits = append(b.jsonEdits, jsonEdit{path: path, delete: true})
+	return b
+}
+
+// editJSON applies the JSON edits to the body.
+func (b *requestBuilder) editJSON(body bodySource) (bodySource, error) {
+	if body == nil || !body.replayable() {
+		return nil, errors.New("testrequest: SetJSONField: the body is not set by SetJSON or SetJSONFromValue")
+	}
+	r, err := body.open()
+	if err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := decodeJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("testrequest: SetJSONField: %w", err)
+	}
+	for _, e := range b.jsonEdits {
+		if e.delete {
+			doc, err = deleteJSONValue(doc, parseJSONPath(e.path))
+		} else {
+			var value interface{}
+			if value, err = toGenericJSON(e.value); err == nil {
+				doc, err = setJSONValue(doc, parseJSONPath(e.path), value)
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("testrequest: JSON field %q: %w", e.path, err)
+		}
+	}
+	if data, err = encodeJSON(doc); err != nil {
+		return nil, fmt.Errorf("testrequest: SetJSONField: %w", err)
+	}
+	if typed, ok := body.(typedBody); ok {
+		return typedBytesBody{bytesBody: data, mediaType: typed.contentType()}, nil
+	}
+	return bytesBody(data), nil
+}
+
+// decodeJSON decodes the data into a generic value, keeping numbers as json.Number.
+func decodeJSON(data []byte) (interface{}, error) {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	var doc interface{}
+	if err := d.Decode(&doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// encodeJSON returns the JSON encoding of the value without escaping HTML characters,
+// so fields which are not edited keep their encoding.
+func encodeJSON(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	e := json.NewEncoder(&buf)
+	e.SetEscapeHTML(false)
+	if err := e.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// toGenericJSON converts the value to a generic value of its JSON encoding,
+// so it can be changed without affecting the caller's value.
+func toGenericJSON(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return decodeJSON(data)
+}
+
+// parseJSONPath splits a JSON Pointer, e.g. /authors/0, see RFC 6901,
+// or a dotted path, e.g. authors.0, into reference tokens.
+func parseJSONPath(path string) []string {
+	if path == "" {
+		return nil
+	}
+	if !strings.HasPrefix(path, "/") {
+		return strings.Split(path, ".")
+	}
+	tokens := strings.Split(path[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = pointerUnescaper.Replace(token)
+	}
+	return tokens
+}
+
+// setJSONValue sets the value at the tokens' location in the node, creating missing objects,
+// and returns the changed node. The index - or the length of an array appends the value.
+func setJSONValue(node interface{}, tokens []string, value interface{}) (interface{}, error) {
+	if len(tokens) == 0 {
+		return value, nil
+	}
+	token, rest := tokens[0], tokens[1:]
+	switch n := node.(type) {
+	case nil:
+		return setJSONValue(map[string]interface{}{}, tokens, value)
+	case map[string]interface{}:
+		child, err := setJSONValue(n[token], rest, value)
+		if err != nil {
+			return nil, err
+		}
+		n[token] = child
+		return n, nil
+	case []interface{}:
+		i, err := arrayIndex(token, len(n), true)
+		if err != nil {
+			return nil, err
+		}
+		if i == len(n) {
+			if len(rest) > 0 {
+				return nil, fmt.Errorf("array index %q out of range", token)
+			}
+			return append(n, value), nil
+		}
+		child, err := setJSONValue(n[i], rest, value)
+		if err != nil {
+			return nil, err
+		}
+		n[i] = child
+		return n, nil
+	}
+	return nil, fmt.Errorf("cannot set %q in %T", token, node)
+}
+
+// deleteJSONValue deletes the value at the tokens' location in the node and returns the changed node.
+func deleteJSONValue(node interface{}, tokens []string) (interface{}, error) {
+	if len(tokens) == 0 {
+		return nil, errors.New("cannot delete the whole document")
+	}
+	token, rest := tokens[0], tokens[1:]
+	switch n := node.(type) {
+	case map[string]interface{}:
+		child, ok := n[token]
+		if !ok {
+			return nil, fmt.Errorf("member %q not found", token)
+		}
+		if len(rest) == 0 {
+			delete(n, token)
+			return n, nil
+		}
+		child, err := deleteJSONValue(child, rest)
+		if err != nil {
+			return nil, err
+		}
+		n[token] = child
+		return n, nil
+	case []interface{}:
+		i, err := arrayIndex(token, len(n), false)
+		if err != nil {
+			return nil, err
+		}
+		if len(rest) == 0 {
+			return append(n[:i:i], n[i+1:]...), nil
+		}
+		child, err := deleteJSONValue(n[i], rest)
+		if err != nil {
+			return nil, err
+		}
+		n[i] = child
+		return n, nil
+	}
+	return nil, fmt.Errorf("cannot delete %q in %T", token, node)
+}
+
+// arrayIndex parses the array index token. If appendable, - or the length is allowed as the index
+// of the next element.
+func arrayIndex(token string, length int, appendable bool) (int, error) {
+	if token == "-" && appendable {
+		return length, nil
+	}
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || i > length || i == length && !appendable {
+		return 0, fmt.Errorf("array index %q out of range", token)
+	}
+	return i, nil
+}
@@ -0,0 +1,139 @@
+package testrequest
+
+import (
+	"encoding/json"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func Test_requestBuilder_SetJSONField(t *testing.T) {
+	book := `{"title":"The Go Programming Language","authors":["Alan A. A. Donovan","Brian W. Kernighan"],"price":{"amount":44.99}}`
+	tests := []struct {
+		name    string
+		b       RequestBuilder
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "DottedPath",
+			b:    Builder().SetJSON([]byte(book)).SetJSONField("price.amount", 0),
+			want: `{"authors":["Alan A. A. Donovan","Brian W. Kernighan"],"price":{"amount":0},"title":"The Go Programming Language"}`,
+		},
+		{
+			name: "JSONPointer",
+			b:    Builder().SetJSON([]byte(book)).SetJSONField("/authors/1", ""),
+			want: `{"authors":["Alan A. A. Donovan",""],"price":{"amount":44.99},"title":"The Go Programming Language"}`,
+		},
+		{
+			name: "EscapedJSONPointer",
+			b:    Builder().SetJSON([]byte(`{}`)).SetJSONField("/a~1b/c~0d", true),
+			want: `{"a/b":{"c~d":true}}`,
+		},
+		{
+			name: "Append",
+			b:    Builder().SetJSON([]byte(`{"tags":["go"]}`)).SetJSONField("tags.-", "http").SetJSONField("tags.2", "test"),
+			want: `{"tags":["go","http","test"]}`,
+		},
+		{
+			name: "CreateObjects",
+			b:    Builder().SetJSON([]byte(`{}`)).SetJSONField("meta.edition", 1),
+			want: `{"meta":{"edition":1}}`,
+		},
+		{
+			name: "Delete",
+			b:    Builder().SetJSON([]byte(book)).DeleteJSONField("title").DeleteJSONField("/authors/0"),
+			want: `{"authors":["Brian W. Kernighan"],"price":{"amount":44.99}}`,
+		},
+		{
+			name: "FromValue",
+			b:    Builder().SetJSONFromValue(map[string]interface{}{"id": 9007199254740993}).SetJSONField("name", "go"),
+			want: `{"id":9007199254740993,"name":"go"}`,
+		},
+		{
+			name: "Root",
+			b:    Builder().SetJSON([]byte(book)).SetJSONField("", nil),
+			want: `null`,
+		},
+		{
+			name: "HTMLCharacters",
+			b:    Builder().SetJSON([]byte(`{"a":"<b>&"}`)).SetJSONField("c", "<i>"),
+			want: `{"a":"<b>&","c":"<i>"}`,
+		},
+		{
+			name:    "MissingField",
+			b:       Builder().SetJSON([]byte(book)).DeleteJSONField("isbn"),
+			wantErr: true,
+		},
+		{
+			name:    "IndexOutOfRange",
+			b:       Builder().SetJSON([]byte(book)).SetJSONField("authors.5", ""),
+			wantErr: true,
+		},
+		{
+			name:    "NotContainer",
+			b:       Builder().SetJSON([]byte(book)).SetJSONField("title.first", ""),
+			wantErr: true,
+		},
+		{
+			name:    "InvalidJSON",
+			b:       Builder().SetJSON([]byte(`{`)).SetJSONField("title", ""),
+			wantErr: true,
+		},
+		{
+			name:    "NoBody",
+			b:       Builder().SetJSONField("title", ""),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := tt.b.Build()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SetJSONField() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			got, _ := io.ReadAll(req.Body)
+			if string(got) != tt.want {
+				t.Errorf("SetJSONField() body = %v, want %v", string(got), tt.want)
+			}
+			if req.ContentLength != int64(len(tt.want)) {
+				t.Errorf("SetJSONField() contentLength = %v, want %v", req.ContentLength, len(tt.want))
+			}
+			if got := req.Header.Get("Content-Type"); got != "application/json;charset=UTF-8" {
+				t.Errorf("SetJSONField() contentType = %v, want %v", got, "application/json;charset=UTF-8")
+			}
+		})
+	}
+}
+
+func Test_requestBuilder_SetJSONField_Clone(t *testing.T) {
+	base := Builder().SetJSONFromValue(map[string]interface{}{"title": "Go"})
+	variant := base.Clone().SetJSONField("title", "")
+	var got map[string]interface{}
+	if err := json.NewDecoder(base.Request().Body).Decode(&got); err != nil || got["title"] != "Go" {
+		t.Errorf("base title = %v, %v", got["title"], err)
+	}
+	if err := json.NewDecoder(variant.Request().Body).Decode(&got); err != nil || got["title"] != "" {
+		t.Errorf("variant title = %v, %v", got["title"], err)
+	}
+}
+
+func Test_requestBuilder_SetJSONField_Value(t *testing.T) {
+	m := map[string]interface{}{"y": 1}
+	b := Builder().SetJSON([]byte(`{}`)).SetJSONField("x", m).SetJSONField("x.y", 2)
+	for i := 0; i < 2; i++ {
+		got, _ := io.ReadAll(b.Clone().Request().Body)
+		if want := `{"x":{"y":2}}`; string(got) != want {
+			t.Errorf("SetJSONField() = %v, want %v", string(got), want)
+		}
+	}
+	if want := map[string]interface{}{"y": 1}; !reflect.DeepEqual(m, want) {
+		t.Errorf("SetJSONField() value = %v, want %v", m, want)
+	}
+	if _, err := Builder().SetJSON([]byte(`{}`)).SetJSONField("x", make(chan int)).Build(); err == nil {
+		t.Errorf("SetJSONField() error = %v, wantErr %v", err, true)
+	}
+}
@@ -0,0 +1,152 @@
+package testrequest
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+type (
+	// multipartForm is the request's multipart/form-data body.
+	multipartForm struct {
+		boundary string
+		parts    []multipartPart
+	}
+	// multipartPart is a field or a file part of multipartForm.
+	multipartPart struct {
+		name        string
+		filename    string
+		contentType string
+		data        []byte
+		file        bool
+	}
+)
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+func (b *requestBuilder) SetMultipartField(name, value string) RequestBuilder {
+	form := b.multipartForm()
+	parts := form.parts[:0]
+	for _, p := range form.parts {
+		if p.file || p.name != name {
+			parts = append(parts, p)
+		}
+	}
+	form.parts = append(parts, multipartPart{name: name, data: []byte(value)})
+	return b
+}
+
+func (b *requestBuilder) AddMultipartFile(field, filename, contentType string, r io.Reader) RequestBuilder {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return b.addError("AddMultipartFile", err)
+	}
+	form := b.multipartForm()
+	form.parts = append(form.parts, multipartPart{
+		name:        field,
+		filename:    filename,
+		contentType: contentType,
+		data:        data,
+		file:        true,
+	})
+	return b
+}
+
+func (b *requestBuilder) AddMultipartFileFromPath(field, name string) RequestBuilder {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return b.addError("AddMultipartFileFromPath", err)
+	}
+	base := filepath.Base(name)
+	return b.AddMultipartFile(field, base, typeByExtension(base), bytes.NewReader(data))
+}
+
+func (b *requestBuilder) AddMultipartFileFromFS(field string, fsys fs.FS, name string) RequestBuilder {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return b.addError("AddMultipartFileFromFS", err)
+	}
+	base := path.Base(name)
+	return b.AddMultipartFile(field, base, typeByExtension(base), bytes.NewReader(data))
+}
+
+func (b *requestBuilder) SetMultipartBoundary(boundary string) RequestBuilder {
+	if err := multipart.NewWriter(io.Discard).SetBoundary(boundary); err != nil {
+		return b.addError("SetMultipartBoundary", err)
+	}
+	b.multipartForm().boundary = boundary
+	return b
+}
+
+// multipartForm returns the request's multipart form, initializing it if necessary.
+func (b *requestBuilder) multipartForm() *multipartForm {
+	if b.multipart == nil {
+		b.multipart = &multipartForm{boundary: multipart.NewWriter(io.Discard).Boundary()}
+		b.SetMethod(http.MethodPost)
+	}
+	return b.multipart
+}
+
+func (f *multipartForm) clone() *multipartForm {
+	return &multipartForm{
+		boundary: f.boundary,
+		parts:    append([]multipartPart(nil), f.parts...),
+	}
+}
+
+func (f *multipartForm) contentType() string {
+	return "multipart/form-data; boundary=" + f.boundary
+}
+
+func (f *multipartForm) encode() (typedBytesBody, error) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	if err := w.SetBoundary(f.boundary); err != nil {
+		return typedBytesBody{}, err
+	}
+	for _, p := range f.parts {
+		var pw io.Writer
+		var err error
+		if p.file {
+			h := make(textproto.MIMEHeader)
+			h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`,
+				quoteEscaper.Replace(p.name), quoteEscaper.Replace(p.filename)))
+			contentType := p.contentType
+			if contentType == "" {
+				contentType = "application/octet-stream"
+			}
+			h.Set("Content-Type", contentType)
+			pw, err = w.CreatePart(h)
+		} else {
+			pw, err = w.CreateFormField(p.name)
+		}
+		if err != nil {
+			return typedBytesBody{}, err
+		}
+		if _, err = pw.Write(p.data); err != nil {
+			return typedBytesBody{}, err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return typedBytesBody{}, err
+	}
+	return typedBytesBody{bytesBody: buf.Bytes(), mediaType: f.contentType()}, nil
+}
+
+// typeByExtension returns the MIME type of the file name's extension,
+// or application/octet-stream if it is unknown.
+func typeByExtension(name string) string {
+	if t := mime.TypeByExtension(filepath.Ext(name)); t != "" {
+		return t
+	}
+	return "application/octet-stream"
+}
@@ -0,0 +1,179 @@
+package testrequest
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"mime/multipart"
+	"net/http"
+	"strings"
+)
+
+type (
+	// A MultipartStream describes a multipart/form-data body which is generated lazily
+	// while it is read, so bodies of any size can be tested without holding them in memory.
+	MultipartStream struct {
+		// Boundary is the boundary of the body. If empty, a random boundary is used.
+		Boundary string
+		// Parts are the parts of the body.
+		Parts []MultipartStreamPart
+		// TruncateAfter, if positive, truncates the body after the number of bytes.
+		// Reading the truncated body returns io.ErrUnexpectedEOF, as if the client disconnected.
+		TruncateAfter int64
+	}
+	// A MultipartStreamPart describes a generated part of a MultipartStream.
+	MultipartStreamPart struct {
+		// FieldName is the name of the form field.
+		FieldName string
+		// FileName is the name of the file. If empty, the part is a plain field.
+		FileName string
+		// ContentType is the Content-Type of the file part.
+		// If empty, it is application/octet-stream.
+		ContentType string
+		// Size is the size of the part's content in bytes.
+		Size int64
+		// Pattern is repeated to generate the content.
+		// If empty, the content is pseudo-random bytes generated from Seed.
+		Pattern []byte
+		// Seed is the seed of the pseudo-random content.
+		Seed uint64
+		// MalformedBoundary adds trailing garbage to the delimiter line preceding the part,
+		// which multipart readers reject as an unexpected line. The delimiter of
+		// the first part is skipped as the preamble instead.
+		MalformedBoundary bool
+	}
+	// multipartStreamBody is the bodySource of a MultipartStream.
+	multipartStreamBody struct {
+		stream MultipartStream
+	}
+	// patternReader repeats the pattern endlessly.
+	patternReader struct {
+		pattern []byte
+		off     int
+	}
+	// randomReader reads pseudo-random bytes endlessly.
+	randomReader struct {
+		rng *rand.Rand
+		buf [8]byte
+		off int
+	}
+	// truncatedReader returns io.ErrUnexpectedEOF once the limited reader is drained.
+	truncatedReader struct {
+		r *io.LimitedReader
+	}
+)
+
+func (b *requestBuilder) SetMultipartStream(stream MultipartStream) RequestBuilder {
+	if stream.Boundary == "" {
+		stream.Boundary = multipart.NewWriter(io.Discard).Boundary()
+	}
+	if err := multipart.NewWriter(io.Discard).SetBoundary(stream.Boundary); err != nil {
+		return b.addError("SetMultipartStream", err)
+	}
+	stream.Parts = append([]MultipartStreamPart(nil), stream.Parts...)
+	b.body = &multipartStreamBody{stream: stream}
+	return b.SetMethod(http.MethodPost)
+}
+
+// segments returns the readers of the body's consecutive segments.
+func (s *multipartStreamBody) segments() []io.Reader {
+	boundary := s.stream.Boundary
+	readers := make([]io.Reader, 0, 2*len(s.stream.Parts)+1)
+	for i, p := range s.stream.Parts {
+		var header strings.Builder
+		if i > 0 {
+			header.WriteString("\r\n")
+		}
+		delimiter := "--" + boundary
+		if p.MalformedBoundary {
+			delimiter += " malformed"
+		}
+		header.WriteString(delimiter + "\r\n")
+		disposition := fmt.Sprintf(`form-data; name="%s"`, quoteEscaper.Replace(p.FieldName))
+		if p.FileName != "" {
+			disposition += fmt.Sprintf(`; filename="%s"`, quoteEscaper.Replace(p.FileName))
+		}
+		header.WriteString("Content-Disposition: " + disposition + "\r\n")
+		if p.FileName != "" {
+			contentType := p.ContentType
+			if contentType == "" {
+				contentType = "application/octet-stream"
+			}
+			header.WriteString("Content-Type: " + contentType + "\r\n")
+		}
+		header.WriteString("\r\n")
+		readers = append(readers, strings.NewReader(header.String()), io.LimitReader(p.content(), p.Size))
+	}
+	closing := "--" + boundary + "--\r\n"
+	if len(s.stream.Parts) > 0 {
+		closing = "\r\n" + closing
+	}
+	return append(readers, strings.NewReader(closing))
+}
+
+func (s *multipartStreamBody) open() (io.Reader, error) {
+	r := io.MultiReader(s.segments()...)
+	if s.stream.TruncateAfter > 0 {
+		return &truncatedReader{r: &io.LimitedReader{R: r, N: s.stream.TruncateAfter}}, nil
+	}
+	return r, nil
+}
+
+func (s *multipartStreamBody) replayable() bool {
+	return true
+}
+
+func (s *multipartStreamBody) size() int64 {
+	var n int64
+	for _, r := range s.segments() {
+		switch r := r.(type) {
+		case *strings.Reader:
+			n += r.Size()
+		case *io.LimitedReader:
+			n += r.N
+		}
+	}
+	return n
+}
+
+func (s *multipartStreamBody) contentType() string {
+	return "multipart/form-data; boundary=" + s.stream.Boundary
+}
+
+func (p MultipartStreamPart) content() io.Reader {
+	if len(p.Pattern) > 0 {
+		return &patternReader{pattern: p.Pattern}
+	}
+	return &randomReader{rng: rand.New(rand.NewPCG(p.Seed, p.Seed)), off: 8}
+}
+
+func (r *patternReader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		c := copy(p[n:], r.pattern[r.off:])
+		n += c
+		r.off = (r.off + c) % len(r.pattern)
+	}
+	return n, nil
+}
+
+func (r *randomReader) Read(p []byte) (int, error) {
+	for i := range p {
+		if r.off == len(r.buf) {
+			binary.LittleEndian.PutUint64(r.buf[:], r.rng.Uint64())
+			r.off = 0
+		}
+		p[i] = r.buf[r.off]
+		r.off++
+	}
+	return len(p), nil
+}
+
+func (r *truncatedReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if err == io.EOF && r.r.N == 0 {
+		err = io.ErrUnexpectedEOF
+	}
+	return n, err
+}
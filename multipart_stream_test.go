@@ -0,0 +1,140 @@
+package testrequest
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func Test_requestBuilder_SetMultipartStream(t *testing.T) {
+	stream := MultipartStream{
+		Boundary: "test-boundary",
+		Parts: []MultipartStreamPart{
+			{FieldName: "title", Size: 4, Pattern: []byte("go")},
+			{FieldName: "file", FileName: "file.bin", Size: 1 << 20, Seed: 1},
+		},
+	}
+	req := Builder().SetMultipartStream(stream).Request()
+	if req.Method != "POST" {
+		t.Errorf("SetMultipartStream() method = %v, want %v", req.Method, "POST")
+	}
+	if want := "multipart/form-data; boundary=test-boundary"; req.Header.Get("Content-Type") != want {
+		t.Errorf("SetMultipartStream() contentType = %v, want %v", req.Header.Get("Content-Type"), want)
+	}
+	body, _ := req.GetBody()
+	data, _ := io.ReadAll(body)
+	if int64(len(data)) != req.ContentLength {
+		t.Errorf("SetMultipartStream() contentLength = %v, want %v", req.ContentLength, len(data))
+	}
+	if err := req.ParseMultipartForm(1 << 10); err != nil {
+		t.Errorf("SetMultipartStream() parse error = %v", err)
+		return
+	}
+	if got := req.MultipartForm.Value["title"]; len(got) != 1 || got[0] != "gogo" {
+		t.Errorf("SetMultipartStream() title = %v, want %v", got, "gogo")
+	}
+	f, h, err := req.FormFile("file")
+	if err != nil {
+		t.Errorf("SetMultipartStream() file error = %v", err)
+		return
+	}
+	defer f.Close()
+	if h.Size != 1<<20 || h.Header.Get("Content-Type") != "application/octet-stream" {
+		t.Errorf("SetMultipartStream() file = %v %v", h.Size, h.Header.Get("Content-Type"))
+	}
+	got, _ := io.ReadAll(f)
+	second, _ := req.GetBody()
+	data, _ = io.ReadAll(second)
+	if !bytes.Contains(data, got) {
+		t.Errorf("SetMultipartStream() content is not deterministic")
+	}
+}
+
+func Test_requestBuilder_SetMultipartStreamLarge(t *testing.T) {
+	size := int64(64 << 20)
+	req := Builder().SetMultipartStream(MultipartStream{
+		Parts: []MultipartStreamPart{{FieldName: "file", FileName: "large.bin", Size: size, Pattern: []byte("0123456789")}},
+	}).Request()
+	mr, err := req.MultipartReader()
+	if err != nil {
+		t.Errorf("MultipartReader() error = %v", err)
+		return
+	}
+	part, err := mr.NextPart()
+	if err != nil {
+		t.Errorf("NextPart() error = %v", err)
+		return
+	}
+	n, err := io.Copy(io.Discard, part)
+	if err != nil || n != size {
+		t.Errorf("SetMultipartStream() part = %v, %v, want %v", n, err, size)
+	}
+	if _, err = mr.NextPart(); err != io.EOF {
+		t.Errorf("NextPart() error = %v, want %v", err, io.EOF)
+	}
+}
+
+func Test_requestBuilder_SetMultipartStreamFaults(t *testing.T) {
+	tests := []struct {
+		name   string
+		stream MultipartStream
+	}{
+		{
+			name: "MalformedBoundary",
+			stream: MultipartStream{
+				Parts: []MultipartStreamPart{
+					{FieldName: "first", Size: 16, Seed: 1},
+					{FieldName: "second", Size: 16, Seed: 2, MalformedBoundary: true},
+				},
+			},
+		},
+		{
+			name: "Truncated",
+			stream: MultipartStream{
+				Parts:         []MultipartStreamPart{{FieldName: "file", FileName: "file.bin", Size: 1 << 16, Seed: 1}},
+				TruncateAfter: 1 << 10,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := Builder().SetMultipartStream(tt.stream).Request()
+			mr, err := req.MultipartReader()
+			if err != nil {
+				t.Errorf("MultipartReader() error = %v", err)
+				return
+			}
+			for err == nil {
+				var part io.Reader
+				if part, err = mr.NextPart(); err == nil {
+					_, err = io.Copy(io.Discard, part)
+				}
+			}
+			if err == io.EOF {
+				t.Errorf("SetMultipartStream() error = %v, want malformed body error", err)
+			}
+		})
+	}
+}
+
+func Test_requestBuilder_SetMultipartStreamTruncated(t *testing.T) {
+	req := Builder().SetMultipartStream(MultipartStream{
+		Parts:         []MultipartStreamPart{{FieldName: "file", Size: 1 << 10, Seed: 1}},
+		TruncateAfter: 100,
+	}).Request()
+	data, err := io.ReadAll(req.Body)
+	if !errors.Is(err, io.ErrUnexpectedEOF) || len(data) != 100 {
+		t.Errorf("SetMultipartStream() = %v, %v, want %v, %v", len(data), err, 100, io.ErrUnexpectedEOF)
+	}
+	if req.ContentLength <= 100 {
+		t.Errorf("SetMultipartStream() contentLength = %v, want full length", req.ContentLength)
+	}
+}
+
+func Test_requestBuilder_SetMultipartStreamBoundary(t *testing.T) {
+	_, err := Builder().SetMultipartStream(MultipartStream{Boundary: "invalid boundary "}).Build()
+	if err == nil {
+		t.Errorf("SetMultipartStream() error = %v, wantErr %v", err, true)
+	}
+}
@@ -0,0 +1,121 @@
+package testrequest
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func Test_requestBuilder_Multipart(t *testing.T) {
+	fsys := fstest.MapFS{"books/book.json": {Data: []byte("{}")}}
+	req := Builder().
+		SetMultipartField("title", "The Go Programming Language").
+		SetMultipartField("isbn", "000").
+		SetMultipartField("isbn", "978-0134190440").
+		AddMultipartFile("text", "book.txt", "text/plain", strings.NewReader("test")).
+		AddMultipartFileFromPath("cover", "testdata/cover.png").
+		AddMultipartFileFromFS("meta", fsys, "books/book.json").
+		Request()
+	if req.Method != "POST" {
+		t.Errorf("Multipart() method = %v, want %v", req.Method, "POST")
+	}
+	if err := req.ParseMultipartForm(1 << 20); err != nil {
+		t.Errorf("Multipart() parse error = %v", err)
+		return
+	}
+	wantValues := url.Values{
+		"title": {"The Go Programming Language"},
+		"isbn":  {"978-0134190440"},
+	}
+	for key, want := range wantValues {
+		if got := req.MultipartForm.Value[key]; len(got) != 1 || got[0] != want[0] {
+			t.Errorf("Multipart() value %v = %v, want %v", key, got, want)
+		}
+	}
+	cover, err := os.ReadFile("testdata/cover.png")
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if got := http.DetectContentType(cover); got != "image/png" {
+		t.Fatalf("DetectContentType() = %v, want %v", got, "image/png")
+	}
+	wantFiles := []struct {
+		field, filename, contentType, data string
+	}{
+		{field: "text", filename: "book.txt", contentType: "text/plain", data: "test"},
+		{field: "cover", filename: "cover.png", contentType: "image/png", data: string(cover)},
+		{field: "meta", filename: "book.json", contentType: "application/json", data: "{}"},
+	}
+	for _, want := range wantFiles {
+		f, h, err := req.FormFile(want.field)
+		if err != nil {
+			t.Errorf("Multipart() file %v error = %v", want.field, err)
+			continue
+		}
+		data, _ := io.ReadAll(f)
+		if h.Filename != want.filename || h.Header.Get("Content-Type") != want.contentType || string(data) != want.data {
+			t.Errorf("Multipart() file %v = %v %v %v, want %v %v %v", want.field,
+				h.Filename, h.Header.Get("Content-Type"), string(data), want.filename, want.contentType, want.data)
+		}
+	}
+}
+
+func Test_requestBuilder_SetMultipartBoundary(t *testing.T) {
+	req := Builder().
+		SetMultipartBoundary("test-boundary").
+		SetMultipartField("test", "test").
+		Request()
+	want := "multipart/form-data; boundary=test-boundary"
+	if got := req.Header.Get("Content-Type"); got != want {
+		t.Errorf("SetMultipartBoundary() = %v, want %v", got, want)
+	}
+	body, _ := io.ReadAll(req.Body)
+	if !strings.HasPrefix(string(body), "--test-boundary\r\n") {
+		t.Errorf("SetMultipartBoundary() body = %v", string(body))
+	}
+	if _, err := Builder().SetMultipartBoundary("").Build(); err == nil {
+		t.Errorf("SetMultipartBoundary() error = %v, wantErr %v", err, true)
+	}
+}
+
+func Test_requestBuilder_MultipartErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder RequestBuilder
+	}{
+		{
+			name:    "MissingFile",
+			builder: Builder().AddMultipartFileFromPath("file", "testdata/missing.png"),
+		},
+		{
+			name:    "MissingFSFile",
+			builder: Builder().AddMultipartFileFromFS("file", fstest.MapFS{}, "missing.png"),
+		},
+		{
+			name:    "PostForm",
+			builder: Builder().SetMultipartField("test", "test").SetPostFormValue("test", "test"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.builder.Build(); err == nil {
+				t.Errorf("Build() error = %v, wantErr %v", err, true)
+			}
+		})
+	}
+}
+
+func Test_requestBuilder_AddMultipartFileDefaultContentType(t *testing.T) {
+	req := Builder().AddMultipartFile("file", "data.bin", "", strings.NewReader("test")).Request()
+	_, h, err := req.FormFile("file")
+	if err != nil {
+		t.Fatalf("FormFile() error = %v", err)
+	}
+	if got := h.Header.Get("Content-Type"); got != "application/octet-stream" {
+		t.Errorf("AddMultipartFile() contentType = %v, want %v", got, "application/octet-stream")
+	}
+}
@@ -0,0 +1,166 @@
+package testrequest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+type (
+	// An NDJSONOption configures the NDJSON request body.
+	NDJSONOption  func(o *ndjsonOptions)
+	ndjsonOptions struct {
+		delay             time.Duration
+		crlf              bool
+		noTrailingNewline bool
+	}
+	// ndjsonStream is the bodySource of NDJSON lines encoded while the body is read.
+	ndjsonStream struct {
+		seq  func(yield func(interface{}) bool)
+		opts ndjsonOptions
+		// oneShot is set for channels, which can be drained only once.
+		oneShot bool
+	}
+	// ndjsonReader is the reader of an ndjsonStream.
+	ndjsonReader struct {
+		s    *ndjsonStream
+		once sync.Once
+		pr   *io.PipeReader
+	}
+)
+
+const ndjsonMediaType = "application/x-ndjson"
+
+// WithLineDelay delays each line after the first one by the duration,
+// so incremental processing of the body can be observed.
+func WithLineDelay(d time.Duration) NDJSONOption {
+	return func(o *ndjsonOptions) {
+		o.delay = d
+	}
+}
+
+// WithCRLF terminates lines by CRLF instead of LF.
+func WithCRLF() NDJSONOption {
+	return func(o *ndjsonOptions) {
+		o.crlf = true
+	}
+}
+
+// WithoutTrailingNewline omits the newline after the last line.
+func WithoutTrailingNewline() NDJSONOption {
+	return func(o *ndjsonOptions) {
+		o.noTrailingNewline = true
+	}
+}
+
+// NDJSONValues returns an iterator over the values for RequestBuilder.SetNDJSONStream.
+func NDJSONValues(values ...interface{}) func(yield func(interface{}) bool) {
+	return func(yield func(interface{}) bool) {
+		for _, v := range values {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+func (b *requestBuilder) SetNDJSON(values ...interface{}) RequestBuilder {
+	var buf bytes.Buffer
+	for _, v := range values {
+		line, err := json.Marshal(v)
+		if err != nil {
+			return b.addError("SetNDJSON", err)
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	return b.setPayload(buf.Bytes(), ndjsonMediaType)
+}
+
+func (b *requestBuilder) SetNDJSONStream(seq func(yield func(interface{}) bool), opts ...NDJSONOption) RequestBuilder {
+	b.body = newNDJSONStream(seq, opts)
+	b.setPayloadDefaults(ndjsonMediaType)
+	return b
+}
+
+func (b *requestBuilder) SetNDJSONChan(ch <-chan interface{}, opts ...NDJSONOption) RequestBuilder {
+	s := newNDJSONStream(func(yield func(interface{}) bool) {
+		for v := range ch {
+			if !yield(v) {
+				return
+			}
+		}
+	}, opts)
+	s.oneShot = true
+	b.body = s
+	b.setPayloadDefaults(ndjsonMediaType)
+	return b
+}
+
+func newNDJSONStream(seq func(yield func(interface{}) bool), opts []NDJSONOption) *ndjsonStream {
+	s := &ndjsonStream{seq: seq}
+	for _, opt := range opts {
+		opt(&s.opts)
+	}
+	return s
+}
+
+// open returns the reader of the encoded lines.
+func (s *ndjsonStream) open() (io.Reader, error) {
+	return &ndjsonReader{s: s}, nil
+}
+
+// Read starts encoding the lines into a pipe on the first call, so an unread body
+// holds no goroutine and takes no values from the sequence.
+func (r *ndjsonReader) Read(p []byte) (int, error) {
+	r.once.Do(r.start)
+	return r.pr.Read(p)
+}
+
+// Close stops the encoding, or prevents it if the body is unread.
+func (r *ndjsonReader) Close() error {
+	r.once.Do(func() {
+		r.pr, _ = io.Pipe()
+	})
+	return r.pr.Close()
+}
+
+func (r *ndjsonReader) start() {
+	pr, pw := io.Pipe()
+	r.pr = pr
+	s := r.s
+	newline := []byte("\n")
+	if s.opts.crlf {
+		newline = []byte("\r\n")
+	}
+	go func() {
+		first := true
+		var err error
+		s.seq(func(v interface{}) bool {
+			if !first && s.opts.delay > 0 {
+				time.Sleep(s.opts.delay)
+			}
+			var line []byte
+			if line, err = json.Marshal(v); err != nil {
+				return false
+			}
+			if s.opts.noTrailingNewline {
+				if !first {
+					line = append(newline[:len(newline):len(newline)], line...)
+				}
+			} else {
+				line = append(line, newline...)
+			}
+			first = false
+			_, err = pw.Write(line)
+			return err == nil
+		})
+		pw.CloseWithError(err)
+	}()
+}
+
+func (s *ndjsonStream) replayable() bool {
+	return !s.oneShot
+}
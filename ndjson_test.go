@@ -0,0 +1,189 @@
+package testrequest
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func Test_requestBuilder_SetNDJSON(t *testing.T) {
+	req := Builder().SetNDJSON(map[string]int{"id": 1}, map[string]int{"id": 2}).Request()
+	if req.Method != http.MethodPost {
+		t.Errorf("SetNDJSON() method = %v, want %v", req.Method, http.MethodPost)
+	}
+	if got := req.Header.Get("Content-Type"); got != "application/x-ndjson" {
+		t.Errorf("SetNDJSON() contentType = %v, want %v", got, "application/x-ndjson")
+	}
+	got, _ := io.ReadAll(req.Body)
+	if want := "{\"id\":1}\n{\"id\":2}\n"; string(got) != want {
+		t.Errorf("SetNDJSON() = %q, want %q", string(got), want)
+	}
+	if _, err := Builder().SetNDJSON(make(chan int)).Build(); err == nil {
+		t.Errorf("SetNDJSON() error = %v, wantErr %v", err, true)
+	}
+}
+
+func Test_requestBuilder_SetNDJSONStream(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []NDJSONOption
+		want string
+	}{
+		{
+			name: "Default",
+			want: "1\n2\n3\n",
+		},
+		{
+			name: "CRLF",
+			opts: []NDJSONOption{WithCRLF()},
+			want: "1\r\n2\r\n3\r\n",
+		},
+		{
+			name: "WithoutTrailingNewline",
+			opts: []NDJSONOption{WithoutTrailingNewline()},
+			want: "1\n2\n3",
+		},
+		{
+			name: "CRLFWithoutTrailingNewline",
+			opts: []NDJSONOption{WithCRLF(), WithoutTrailingNewline()},
+			want: "1\r\n2\r\n3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := Builder().SetNDJSONStream(NDJSONValues(1, 2, 3), tt.opts...).Request()
+			got, err := io.ReadAll(req.Body)
+			if err != nil || string(got) != tt.want {
+				t.Errorf("SetNDJSONStream() = %q, %v, want %q", string(got), err, tt.want)
+			}
+			body, _ := req.GetBody()
+			got, _ = io.ReadAll(body)
+			if string(got) != tt.want {
+				t.Errorf("GetBody() = %q, want %q", string(got), tt.want)
+			}
+		})
+	}
+}
+
+func Test_requestBuilder_SetNDJSONStreamDelay(t *testing.T) {
+	delay := 20 * time.Millisecond
+	req := Builder().SetNDJSONStream(NDJSONValues(1, 2, 3), WithLineDelay(delay)).Request()
+	scanner := bufio.NewScanner(req.Body)
+	var times []time.Time
+	for scanner.Scan() {
+		times = append(times, time.Now())
+	}
+	if len(times) != 3 {
+		t.Errorf("SetNDJSONStream() lines = %v, want %v", len(times), 3)
+		return
+	}
+	for i := 1; i < len(times); i++ {
+		if d := times[i].Sub(times[i-1]); d < delay {
+			t.Errorf("SetNDJSONStream() delay = %v, want at least %v", d, delay)
+		}
+	}
+}
+
+func Test_requestBuilder_SetNDJSONStreamError(t *testing.T) {
+	req := Builder().SetNDJSONStream(NDJSONValues(1, make(chan int))).Request()
+	got, err := io.ReadAll(req.Body)
+	var jsonErr *json.UnsupportedTypeError
+	if !errors.As(err, &jsonErr) || string(got) != "1\n" {
+		t.Errorf("SetNDJSONStream() = %q, %v, want encoding error", string(got), err)
+	}
+}
+
+func Test_requestBuilder_SetNDJSONChan(t *testing.T) {
+	ch := make(chan interface{})
+	go func() {
+		defer close(ch)
+		ch <- "first"
+		ch <- "second"
+	}()
+	req := Builder().SetNDJSONChan(ch).Request()
+	if req.GetBody != nil {
+		t.Errorf("SetNDJSONChan() getBody is not nil")
+	}
+	got, _ := io.ReadAll(req.Body)
+	if want := "\"first\"\n\"second\"\n"; string(got) != want {
+		t.Errorf("SetNDJSONChan() = %q, want %q", string(got), want)
+	}
+}
+
+func Test_requestBuilder_SetNDJSONStreamClose(t *testing.T) {
+	done := make(chan struct{})
+	seq := func(yield func(interface{}) bool) {
+		defer close(done)
+		for i := 0; yield(i); i++ {
+		}
+	}
+	req := Builder().SetNDJSONStream(seq).Request()
+	bufio.NewReader(req.Body).ReadString('\n')
+	req.Body.Close()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Errorf("SetNDJSONStream() iteration is not stopped by Close")
+	}
+}
+
+func Test_requestBuilder_SetNDJSONStreamUnread(t *testing.T) {
+	before := runtime.NumGoroutine()
+	started := false
+	seq := func(yield func(interface{}) bool) {
+		started = true
+		yield(1)
+	}
+	for i := 0; i < 50; i++ {
+		req := Builder().SetNDJSONStream(seq).Request()
+		if _, err := req.GetBody(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ch := make(chan interface{}, 1)
+	ch <- "first"
+	req := Builder().SetNDJSONChan(ch).Request()
+	time.Sleep(10 * time.Millisecond)
+	if got := runtime.NumGoroutine(); got > before {
+		t.Errorf("SetNDJSONStream() goroutines = %v, want %v", got, before)
+	}
+	if started {
+		t.Errorf("SetNDJSONStream() iteration is started before the body is read")
+	}
+	if len(ch) != 1 {
+		t.Errorf("SetNDJSONChan() took a value before the body is read")
+	}
+	req.Body.Close()
+	if _, err := req.Body.Read(make([]byte, 1)); !errors.Is(err, io.ErrClosedPipe) {
+		t.Errorf("SetNDJSONChan() read after Close error = %v, want %v", err, io.ErrClosedPipe)
+	}
+	if len(ch) != 1 {
+		t.Errorf("SetNDJSONChan() took a value after Close")
+	}
+}
+
+func Test_requestBuilder_SetNDJSONStreamGetBodyClose(t *testing.T) {
+	done := make(chan struct{}, 2)
+	seq := func(yield func(interface{}) bool) {
+		defer func() { done <- struct{}{} }()
+		for i := 0; yield(i); i++ {
+		}
+	}
+	req := Builder().SetNDJSONStream(seq).Request()
+	body, err := req.GetBody()
+	if err != nil {
+		t.Fatalf("GetBody() error = %v", err)
+	}
+	io.ReadFull(body, make([]byte, 10))
+	body.Close()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Errorf("GetBody() iteration is not stopped by Close")
+	}
+}
@@ -0,0 +1,55 @@
+package testrequest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type pathTemplateKey struct{}
+
+// PathTemplate returns the raw path template set by RequestBuilder.SetPathTemplate
+// for the request, or an empty string if the request was built without a template.
+func PathTemplate(r *http.Request) string {
+	tmpl, _ := r.Context().Value(pathTemplateKey{}).(string)
+	return tmpl
+}
+
+func (b *requestBuilder) SetPathTemplate(tmpl string) RequestBuilder {
+	b.pathTemplate = tmpl
+	return b
+}
+
+func (b *requestBuilder) SetPathParam(name, value string) RequestBuilder {
+	if b.pathParams == nil {
+		b.pathParams = map[string]string{}
+	}
+	b.pathParams[name] = value
+	return b
+}
+
+// expandPath replaces {name} segments of the template with escaped path parameters.
+func expandPath(tmpl string, params map[string]string) (string, error) {
+	var sb strings.Builder
+	for {
+		i := strings.IndexByte(tmpl, '{')
+		if i < 0 {
+			sb.WriteString(tmpl)
+			return sb.String(), nil
+		}
+		j := strings.IndexByte(tmpl[i:], '}')
+		if j < 0 {
+			return "", errors.New("unclosed '{' in path template")
+		}
+		name := tmpl[i+1 : i+j]
+		value, ok := params[name]
+		if !ok {
+			return "", fmt.Errorf("missing path parameter %q", name)
+		}
+		sb.WriteString(tmpl[:i])
+		sb.WriteString(url.PathEscape(value))
+		tmpl = tmpl[i+j+1:]
+	}
+}
@@ -0,0 +1,88 @@
+package testrequest
+
+import (
+	"testing"
+)
+
+func Test_requestBuilder_SetPathTemplate(t *testing.T) {
+	tests := []struct {
+		name        string
+		builder     RequestBuilder
+		wantPath    string
+		wantRawPath string
+		wantErr     bool
+	}{
+		{
+			name: "Params",
+			builder: Builder().
+				SetPathTemplate("/books/{id}/authors/{authorID}").
+				SetPathParam("id", "978 0134").
+				SetPathParam("authorID", "1"),
+			wantPath:    "/books/978 0134/authors/1",
+			wantRawPath: "/books/978%200134/authors/1",
+		},
+		{
+			name: "EscapedSlash",
+			builder: Builder().
+				SetPathTemplate("/books/{id}").
+				SetPathParam("id", "a/b"),
+			wantPath:    "/books/a/b",
+			wantRawPath: "/books/a%2Fb",
+		},
+		{
+			name: "TargetPath",
+			builder: Builder().
+				SetTarget("https://server.test/v1/?page=2").
+				SetPathTemplate("/books/{id}").
+				SetPathParam("id", "1"),
+			wantPath:    "/v1/books/1",
+			wantRawPath: "/v1/books/1",
+		},
+		{
+			name:    "MissingParam",
+			builder: Builder().SetPathTemplate("/books/{id}"),
+			wantErr: true,
+		},
+		{
+			name:    "UnclosedParam",
+			builder: Builder().SetPathTemplate("/books/{id").SetPathParam("id", "1"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := tt.builder.Build()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SetPathTemplate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if req.URL.Path != tt.wantPath {
+				t.Errorf("SetPathTemplate() path = %v, want %v", req.URL.Path, tt.wantPath)
+			}
+			if got := req.URL.EscapedPath(); got != tt.wantRawPath {
+				t.Errorf("SetPathTemplate() escapedPath = %v, want %v", got, tt.wantRawPath)
+			}
+		})
+	}
+}
+
+func TestPathTemplate(t *testing.T) {
+	want := "/books/{id}"
+	req := Builder().
+		SetContextValue("test", "test").
+		SetPathTemplate(want).
+		SetPathParam("id", "1").
+		Request()
+	if got := PathTemplate(req); got != want {
+		t.Errorf("PathTemplate() = %v, want %v", got, want)
+	}
+	if got := req.Context().Value("test"); got != "test" {
+		t.Errorf("PathTemplate() contextValue = %v, want %v", got, "test")
+	}
+	if got := PathTemplate(Builder().Request()); got != "" {
+		t.Errorf("PathTemplate() = %v, want empty", got)
+	}
+}
@@ -0,0 +1,65 @@
+package testrequest
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// MatchPattern matches the request against the http.ServeMux pattern, e.g. GET /books/{id},
+// and sets the request's path values for the pattern's wildcards, as http.ServeMux does.
+// It returns an error if the pattern is invalid or does not match the request.
+func MatchPattern(r *http.Request, pattern string) (err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = fmt.Errorf("testrequest: invalid pattern %q: %v", pattern, rec)
+		}
+	}()
+	var values map[string]string
+	mux := http.NewServeMux()
+	mux.HandleFunc(pattern, func(w http.ResponseWriter, req *http.Request) {
+		values = map[string]string{}
+		for _, name := range patternWildcards(pattern) {
+			values[name] = req.PathValue(name)
+		}
+	})
+	mux.ServeHTTP(NopResponseWriter(), r)
+	if values == nil {
+		return fmt.Errorf("testrequest: %s %s does not match pattern %q", r.Method, r.URL.Path, pattern)
+	}
+	for name, value := range values {
+		r.SetPathValue(name, value)
+	}
+	return nil
+}
+
+// patternWildcards returns the wildcard names of the http.ServeMux pattern.
+func patternWildcards(pattern string) []string {
+	var names []string
+	if i := strings.IndexByte(pattern, '/'); i >= 0 {
+		pattern = pattern[i:]
+	}
+	for _, segment := range strings.Split(pattern, "/") {
+		if !strings.HasPrefix(segment, "{") || !strings.HasSuffix(segment, "}") {
+			continue
+		}
+		name := strings.TrimSuffix(segment[1:len(segment)-1], "...")
+		if name != "$" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func (b *requestBuilder) SetPathValue(name, value string) RequestBuilder {
+	if b.pathValues == nil {
+		b.pathValues = map[string]string{}
+	}
+	b.pathValues[name] = value
+	return b
+}
+
+func (b *requestBuilder) SetPathPattern(pattern string) RequestBuilder {
+	b.pathPattern = pattern
+	return b
+}
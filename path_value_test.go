@@ -0,0 +1,108 @@
+package testrequest
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_requestBuilder_SetPathValue(t *testing.T) {
+	req := Builder().
+		SetTarget("https://server.test/books/1").
+		SetPathValue("id", "1").
+		Request()
+	if got := req.PathValue("id"); got != "1" {
+		t.Errorf("SetPathValue() = %v, want %v", got, "1")
+	}
+}
+
+func Test_requestBuilder_SetPathPattern(t *testing.T) {
+	tests := []struct {
+		name       string
+		builder    RequestBuilder
+		wantValues map[string]string
+		wantErr    bool
+	}{
+		{
+			name: "Match",
+			builder: Builder().
+				SetTarget("https://server.test/books/1/authors/2").
+				SetPathPattern("GET /books/{id}/authors/{authorID}"),
+			wantValues: map[string]string{"id": "1", "authorID": "2"},
+		},
+		{
+			name: "RemainingSegments",
+			builder: Builder().
+				SetTarget("https://server.test/files/a/b.txt").
+				SetPathPattern("/files/{path...}"),
+			wantValues: map[string]string{"path": "a/b.txt"},
+		},
+		{
+			name: "PathValuePrecedence",
+			builder: Builder().
+				SetTarget("https://server.test/books/1").
+				SetPathPattern("/books/{id}").
+				SetPathValue("id", "2"),
+			wantValues: map[string]string{"id": "2"},
+		},
+		{
+			name: "PathTemplate",
+			builder: Builder().
+				SetPathTemplate("/books/{id}").
+				SetPathParam("id", "978 0134").
+				SetPathPattern("/books/{id}"),
+			wantValues: map[string]string{"id": "978 0134"},
+		},
+		{
+			name: "MethodMismatch",
+			builder: Builder().
+				SetTarget("https://server.test/books/1").
+				SetPathPattern("POST /books/{id}"),
+			wantErr: true,
+		},
+		{
+			name: "PathMismatch",
+			builder: Builder().
+				SetTarget("https://server.test/authors/1").
+				SetPathPattern("/books/{id}"),
+			wantErr: true,
+		},
+		{
+			name:    "InvalidPattern",
+			builder: Builder().SetPathPattern("/books/{id"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := tt.builder.Build()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SetPathPattern() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			got := map[string]string{}
+			for name := range tt.wantValues {
+				got[name] = req.PathValue(name)
+			}
+			if !reflect.DeepEqual(got, tt.wantValues) {
+				t.Errorf("SetPathPattern() = %v, want %v", got, tt.wantValues)
+			}
+		})
+	}
+}
+
+func TestMatchPattern(t *testing.T) {
+	req := Builder().SetTarget("https://server.test/books/1").Request()
+	if err := MatchPattern(req, "GET server.test/books/{id}/{$}"); err == nil {
+		t.Errorf("MatchPattern() error = %v, wantErr %v", err, true)
+	}
+	if err := MatchPattern(req, "GET server.test/books/{id}"); err != nil {
+		t.Errorf("MatchPattern() error = %v, wantErr %v", err, false)
+		return
+	}
+	if got := req.PathValue("id"); got != "1" {
+		t.Errorf("MatchPattern() = %v, want %v", got, "1")
+	}
+}
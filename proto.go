@@ -0,0 +1,71 @@
+package testrequest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+func (b *requestBuilder) SetProto(proto string) RequestBuilder {
+	if _, _, ok := http.ParseHTTPVersion(proto); !ok {
+		return b.addError("SetProto", fmt.Errorf("invalid HTTP version %q", proto))
+	}
+	b.proto = proto
+	return b
+}
+
+func (b *requestBuilder) HTTP2() RequestBuilder {
+	return b.SetProto("HTTP/2.0")
+}
+
+func (b *requestBuilder) HTTP10() RequestBuilder {
+	return b.SetProto("HTTP/1.0")
+}
+
+// setProto sets the request's protocol version and connection semantics
+// the way net/http server does.
+func (b *requestBuilder) setProto(req *http.Request) error {
+	if b.proto != "" {
+		req.Proto = b.proto
+		req.ProtoMajor, req.ProtoMinor, _ = http.ParseHTTPVersion(b.proto)
+	}
+	connection := headerValues(req.Header, "Connection")
+	switch {
+	case req.ProtoMajor >= 2:
+		if len(connection) > 0 {
+			return errors.New("testrequest: SetProto: connection-specific header Connection is not allowed in HTTP/2")
+		}
+		req.Close = false
+		req.RequestURI = req.URL.RequestURI()
+	case req.ProtoMajor == 1 && req.ProtoMinor == 0:
+		req.Close = !hasToken(connection, "keep-alive")
+	default:
+		req.Close = hasToken(connection, "close")
+	}
+	return nil
+}
+
+// headerValues returns the values of the header key, matched case-insensitively,
+// so raw keys set with SetRawHeaderKeys are found too.
+func headerValues(h http.Header, key string) []string {
+	var values []string
+	for k, v := range h {
+		if strings.EqualFold(k, key) {
+			values = append(values, v...)
+		}
+	}
+	return values
+}
+
+// hasToken reports whether the comma-separated header values contain the token.
+func hasToken(values []string, token string) bool {
+	for _, value := range values {
+		for _, t := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(t), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,107 @@
+package testrequest
+
+import (
+	"testing"
+)
+
+func Test_requestBuilder_SetProto(t *testing.T) {
+	tests := []struct {
+		name           string
+		builder        RequestBuilder
+		wantProto      string
+		wantMajor      int
+		wantMinor      int
+		wantClose      bool
+		wantRequestURI string
+		wantErr        bool
+	}{
+		{
+			name:           "Default",
+			builder:        Builder().SetTarget("https://server.test/books?page=2"),
+			wantProto:      "HTTP/1.1",
+			wantMajor:      1,
+			wantMinor:      1,
+			wantRequestURI: "https://server.test/books?page=2",
+		},
+		{
+			name:           "HTTP11Close",
+			builder:        Builder().SetTarget("/books").SetHeader("Connection", "close"),
+			wantProto:      "HTTP/1.1",
+			wantMajor:      1,
+			wantMinor:      1,
+			wantClose:      true,
+			wantRequestURI: "/books",
+		},
+		{
+			name:           "HTTP11RawKeyClose",
+			builder:        Builder().SetTarget("/books").SetRawHeaderKeys(true).SetHeader("connection", "close"),
+			wantProto:      "HTTP/1.1",
+			wantMajor:      1,
+			wantMinor:      1,
+			wantClose:      true,
+			wantRequestURI: "/books",
+		},
+		{
+			name:           "HTTP10",
+			builder:        Builder().SetTarget("/books").HTTP10(),
+			wantProto:      "HTTP/1.0",
+			wantMajor:      1,
+			wantMinor:      0,
+			wantClose:      true,
+			wantRequestURI: "/books",
+		},
+		{
+			name:           "HTTP10KeepAlive",
+			builder:        Builder().SetTarget("/books").HTTP10().SetHeader("Connection", "Keep-Alive"),
+			wantProto:      "HTTP/1.0",
+			wantMajor:      1,
+			wantMinor:      0,
+			wantRequestURI: "/books",
+		},
+		{
+			name:           "HTTP2",
+			builder:        Builder().SetTarget("https://server.test/books?page=2").HTTP2(),
+			wantProto:      "HTTP/2.0",
+			wantMajor:      2,
+			wantMinor:      0,
+			wantRequestURI: "/books?page=2",
+		},
+		{
+			name:    "HTTP2Connection",
+			builder: Builder().HTTP2().SetHeader("Connection", "keep-alive"),
+			wantErr: true,
+		},
+		{
+			name:    "HTTP2RawKeyConnection",
+			builder: Builder().HTTP2().SetRawHeaderKeys(true).SetHeader("connection", "x"),
+			wantErr: true,
+		},
+		{
+			name:    "InvalidProto",
+			builder: Builder().SetProto("HTTP/one"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := tt.builder.Build()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SetProto() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if req.Proto != tt.wantProto || req.ProtoMajor != tt.wantMajor || req.ProtoMinor != tt.wantMinor {
+				t.Errorf("SetProto() = %v %v.%v, want %v %v.%v",
+					req.Proto, req.ProtoMajor, req.ProtoMinor, tt.wantProto, tt.wantMajor, tt.wantMinor)
+			}
+			if req.Close != tt.wantClose {
+				t.Errorf("SetProto() close = %v, want %v", req.Close, tt.wantClose)
+			}
+			if req.RequestURI != tt.wantRequestURI {
+				t.Errorf("SetProto() requestURI = %v, want %v", req.RequestURI, tt.wantRequestURI)
+			}
+		})
+	}
+}
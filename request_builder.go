@@ -3,28 +3,145 @@ package testrequest
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	"io/fs"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"slices"
 	"strings"
+	"testing"
 )
 
 type (
 	// A RequestBuilder interface defines methods for constructing a test http.Request.
 	RequestBuilder interface {
-		// SetHeader sets the request's target.
+		// SetTarget sets the request's target.
+		//
+		// Query parameters of the target are merged into the request's query:
+		// they replace parameters with the same key set before,
+		// and can be changed later by SetQueryValue and AddQueryValue.
+		// A query that cannot be parsed is kept verbatim as by SetRawQuery.
+		//
+		// Parameters and the raw query set by a previous target are replaced,
+		// unless they were changed since.
 		SetTarget(target string) RequestBuilder
+		// SetPathTemplate sets the request's path template, e.g. /books/{id}/authors/{authorID}.
+		//
+		// The path is built by replacing each {name} of the template with the escaped
+		// value set by SetPathParam and is appended to the target's path.
+		// The raw template is available from the built request with PathTemplate.
+		SetPathTemplate(tmpl string) RequestBuilder
+		// SetPathParam sets the value of the path template's {name} parameter.
+		SetPathParam(name, value string) RequestBuilder
+		// SetPathValue sets the request's path value returned by http.Request.PathValue,
+		// for testing handlers written for http.ServeMux without routing the request.
+		SetPathValue(name, value string) RequestBuilder
+		// SetPathPattern sets the http.ServeMux pattern, e.g. GET /books/{id}, which the request
+		// is matched against to set its path values. See MatchPattern.
+		//
+		// Values set by SetPathValue take precedence over the matched ones.
+		SetPathPattern(pattern string) RequestBuilder
 		// SetMethod sets the request's HTTP method.
 		SetMethod(method string) RequestBuilder
-		// SetQuery sets the query for the request.
+		// SetQuery sets the query for the request,
+		// replacing all parameters set before, including the target's ones.
 		SetQuery(q url.Values) RequestBuilder
 		// SetQueryValue sets the query parameter for the request.
+		// It replaces any existing values of the key.
 		SetQueryValue(key string, value ...string) RequestBuilder
+		// AddQueryValue adds the values to the query parameter for the request.
+		// It appends to any existing values of the key.
+		AddQueryValue(key string, value ...string) RequestBuilder
+		// SetQueryFromValue encodes the struct as the query for the request like SetQuery,
+		// see SetPostFormFromValue for the encoding. An error encoding the value is reported by Build.
+		SetQueryFromValue(v interface{}, opts ...FormOption) RequestBuilder
+		// SetRawQuery sets the request's encoded query verbatim,
+		// without validation or normalization, e.g. a=1;b=2 or b=2&a=1&b=1.
+		// If the raw query is not empty, it overrides parameters set by
+		// the target, SetQuery, SetQueryValue and AddQueryValue.
+		SetRawQuery(rawQuery string) RequestBuilder
 		// SetHeader sets the header for the request.
+		// It replaces any existing values of the key, compared case-insensitively.
+		//
+		// The key is canonicalized by http.CanonicalHeaderKey unless raw header keys are enabled.
 		SetHeader(key string, value ...string) RequestBuilder
+		// AddHeader adds the values to the header for the request.
+		// It appends to any existing values of the key.
+		//
+		// The key is canonicalized by http.CanonicalHeaderKey unless raw header keys are enabled.
+		AddHeader(key string, value ...string) RequestBuilder
+		// DelHeader deletes the header for the request.
+		// All keys equal to the key case-insensitively are deleted.
+		DelHeader(key string) RequestBuilder
+		// SetRawHeaderKeys enables or disables raw header keys.
+		// When enabled, keys of headers set afterwards are kept verbatim in the request's Header,
+		// for testing handlers that access the http.Header map directly by non-canonical keys.
+		SetRawHeaderKeys(raw bool) RequestBuilder
+		// SetProto sets the request's protocol version, e.g. HTTP/1.0 or HTTP/2.0.
+		// By default it is HTTP/1.1.
+		//
+		// The request's Close is set as by net/http server: HTTP/1.0 connections are closed
+		// unless the Connection header contains keep-alive, HTTP/1.1 connections are closed
+		// if it contains close. HTTP/2 requests must not have the Connection header,
+		// and their RequestURI is set to the path and query as of the :path pseudo-header.
+		SetProto(proto string) RequestBuilder
+		// HTTP2 sets the request's protocol version as HTTP/2.0.
+		HTTP2() RequestBuilder
+		// HTTP10 sets the request's protocol version as HTTP/1.0.
+		HTTP10() RequestBuilder
+		// SetContentLength sets the request's ContentLength. By default it is set by
+		// httptest.NewRequest for *bytes.Buffer, *bytes.Reader and *strings.Reader bodies only.
+		//
+		// Like net/http server, the body is read up to the declared length, and reading a shorter
+		// body returns io.ErrUnexpectedEOF, so a length mismatching the body can be declared.
+		SetContentLength(n int64) RequestBuilder
+		// UnknownContentLength sets the request's ContentLength as unknown -1.
+		UnknownContentLength() RequestBuilder
+		// SetContentLengthMismatch declares the request's ContentLength as the actual length
+		// of the body plus delta, which is larger or smaller than the body, see SetContentLength.
+		// Building the request fails if the length of the body is unknown.
+		SetContentLengthMismatch(delta int64) RequestBuilder
+		// SetChunked sets the request's transfer encoding as chunked with unknown ContentLength -1,
+		// as the request is received from a client streaming the body.
+		// For HTTP/2 requests only ContentLength is set, since HTTP/2 has no chunked encoding.
+		SetChunked() RequestBuilder
+		// SetTrailer sets the request's trailer and the transfer encoding as chunked.
+		//
+		// Like net/http server, the trailer keys are declared in the request's Trailer with nil values,
+		// and the values are filled only once the body is read to EOF.
+		SetTrailer(key string, value ...string) RequestBuilder
+		// SetRemoteAddr sets the request's RemoteAddr, e.g. 198.51.100.7:54321.
+		// By default it is 192.0.2.1:1234.
+		SetRemoteAddr(addr string) RequestBuilder
+		// SetHost sets the request's Host, which by default is the host of the target.
+		// The URL of the request is not changed.
+		SetHost(host string) RequestBuilder
+		// SetClientIP sets the IP address of the client, IPv4 or IPv6.
+		//
+		// Without headers, the address is set as the request's RemoteAddr.
+		// Otherwise, the address is reported in the headers as a proxy does,
+		// and RemoteAddr is left as the address of the proxy.
+		SetClientIP(ip string, headers ...ProxyHeader) RequestBuilder
+		// SetTLS sets the request's TLS connection state.
+		// By default it is set by httptest.NewRequest only if the target's scheme is https.
+		SetTLS(state *tls.ConnectionState) RequestBuilder
+		// WithoutTLS removes the request's TLS connection state, even if the target's scheme is https.
+		WithoutTLS() RequestBuilder
+		// SetClientCertificate generates an in-memory client certificate with the subject and
+		// subject alternative names, signed by a self-signed CA, and sets the chain as the peer
+		// and verified certificates of the request's TLS connection state.
+		//
+		// SANs are detected by their form: IP addresses, URIs such as spiffe://cluster/service,
+		// email addresses and DNS names.
+		SetClientCertificate(subject pkix.Name, sans ...string) RequestBuilder
 		// SetContentType sets the request's Content-Type header.
 		//
 		// Example of a value: application/json; charset=UTF-8.
@@ -44,7 +161,18 @@ type (
 		// SetContextValue sets the request's context value.
 		SetContextValue(key, value interface{}) RequestBuilder
 		// SetBody sets the request's body.
+		// See FaultReader for bodies injecting faults while they are read.
+		//
+		// Like http.NewRequest, the unread data of *bytes.Buffer, *bytes.Reader and *strings.Reader
+		// is taken as an in-memory body which can be read again, and the request's GetBody is set.
+		// Other readers are read once, so the request's GetBody is not set.
+		// Use SetBodyBytes, SetBodyString or RepeatableRequest for a body which can be read again.
 		SetBody(reader io.Reader) RequestBuilder
+		// SetBodyBytes sets the data as the request's body.
+		// The data must not be modified until the request is built.
+		SetBodyBytes(data []byte) RequestBuilder
+		// SetBodyString sets the string as the request's body.
+		SetBodyString(s string) RequestBuilder
 		// SetPostForm sets the request's body as PostForm.
 		// If PostForm is nil, it is initialized. The method is set as POST.
 		// Content type as application/x-www-form-urlencoded.
@@ -53,6 +181,40 @@ type (
 		// If PostForm is nil, it is initialized. The method is set as POST.
 		// Content type is set as application/x-www-form-urlencoded.
 		SetPostFormValue(key string, value ...string) RequestBuilder
+		// SetPostFormFromValue encodes the struct as the request's PostForm like SetPostForm.
+		// An error encoding the value is reported by Build.
+		//
+		// Field keys are set by form tags, or url tags if not set, e.g. `form:"name,omitempty"`,
+		// and default to the field names. The tag - skips the field, omitempty skips an empty one.
+		// Nested structs and maps are encoded as parent.field, or parent[field] with WithBracketNotation,
+		// slices as repeated keys, nil pointers as empty values and time.Time values in the layout
+		// set by WithTimeLayout.
+		SetPostFormFromValue(v interface{}, opts ...FormOption) RequestBuilder
+		// SetMultipartField sets the field of the request's multipart/form-data body,
+		// replacing fields with the same name. The method is set as POST.
+		//
+		// If Content-Type header is not set, then the value is set as multipart/form-data with the boundary.
+		SetMultipartField(name, value string) RequestBuilder
+		// AddMultipartFile adds the file part read from the reader to the request's
+		// multipart/form-data body. The method is set as POST.
+		// An empty content type is set as application/octet-stream.
+		// An error reading the file is reported by Build.
+		AddMultipartFile(field, filename, contentType string, r io.Reader) RequestBuilder
+		// AddMultipartFileFromPath adds the file, e.g. testdata/cover.png, to the request's
+		// multipart/form-data body like AddMultipartFile.
+		// The content type is detected by the file's extension.
+		AddMultipartFileFromPath(field, name string) RequestBuilder
+		// AddMultipartFileFromFS adds the file of the fsys to the request's
+		// multipart/form-data body like AddMultipartFileFromPath.
+		AddMultipartFileFromFS(field string, fsys fs.FS, name string) RequestBuilder
+		// SetMultipartBoundary sets the boundary of the request's multipart/form-data body.
+		// By default a random boundary is used.
+		SetMultipartBoundary(boundary string) RequestBuilder
+		// SetMultipartStream sets the request's body as the multipart/form-data stream,
+		// generated lazily while the body is read. The method is set as POST.
+		//
+		// If Content-Type header is not set, then the value is set as multipart/form-data with the boundary.
+		SetMultipartStream(stream MultipartStream) RequestBuilder
 		// SetJSON sets JSON-encoded data to the request body.
 		//
 		// If HTTP method is not set or GET or DELETE, the value is set as POST.
@@ -60,12 +222,101 @@ type (
 		// If Content-Type header is not set, then the value is set as application/json;charset=UTF8.
 		SetJSON(data []byte) RequestBuilder
 		// SetJSONFromValue converts the value to JSON encoding
-		// and sets data to the request body. An error encoding the value will cause a panic.
+		// and sets data to the request body. An error encoding the value is reported by Build.
 		//
 		// If HTTP method is not set or GET or DELETE, the value is set as POST.
 		//
 		// If Content-Type header is not set, then the value is set as application/json;charset=UTF8.
 		SetJSONFromValue(v interface{}) RequestBuilder
+		// SetJSONField sets the value of the field in the JSON body set by SetJSON or SetJSONFromValue,
+		// so one fixture can yield variants of it. The path is a JSON Pointer, e.g. /authors/0,
+		// see RFC 6901, or a dotted path, e.g. authors.0. Missing objects on the path are created,
+		// and the index - or the length of an array appends the value.
+		//
+		// The changes are applied in order when the request is built, re-encoding the body
+		// with object keys sorted. An invalid path or body is reported by Build.
+		SetJSONField(path string, value interface{}) RequestBuilder
+		// DeleteJSONField deletes the field in the JSON body like SetJSONField.
+		DeleteJSONField(path string) RequestBuilder
+		// SetJSONPatch sets the JSON Patch document of the operations, see RFC 6902, to the request body.
+		//
+		// If HTTP method is not set or GET or DELETE, the value is set as PATCH.
+		//
+		// If Content-Type header is not set, then the value is set as application/json-patch+json.
+		SetJSONPatch(ops ...PatchOp) RequestBuilder
+		// SetMergePatch converts the value to the JSON Merge Patch document, see RFC 7396,
+		// and sets it to the request body. An error encoding the value is reported by Build.
+		//
+		// If HTTP method is not set or GET or DELETE, the value is set as PATCH.
+		//
+		// If Content-Type header is not set, then the value is set as application/merge-patch+json.
+		SetMergePatch(v interface{}) RequestBuilder
+		// SetXML sets XML-encoded data to the request body.
+		//
+		// If HTTP method is not set or GET or DELETE, the value is set as POST.
+		//
+		// If Content-Type header is not set, then the value is set as application/xml;charset=UTF-8,
+		// or the charset set by WithXMLCharset.
+		SetXML(data []byte, opts ...XMLOption) RequestBuilder
+		// SetXMLFromValue converts the value to XML encoding by encoding/xml
+		// and sets data to the request body like SetXML. An error encoding the value is reported by Build.
+		SetXMLFromValue(v interface{}, opts ...XMLOption) RequestBuilder
+		// SetBodyFromValue encodes the value by the codec registered for the media type,
+		// ignoring its parameters, and sets data to the request body. See RegisterCodec.
+		// An error encoding the value or a missing codec is reported by Build.
+		//
+		// If HTTP method is not set or GET or DELETE, the value is set as POST.
+		//
+		// If Content-Type header is not set, then the value is set as the media type.
+		SetBodyFromValue(mediaType string, v interface{}) RequestBuilder
+		// SetBodyWithCodec encodes the value by the codec, which is not required to be registered,
+		// and sets data to the request body like SetBodyFromValue with the codec's media type.
+		SetBodyWithCodec(c Codec, v interface{}) RequestBuilder
+		// SetNDJSON sets the values encoded as JSON lines to the request body,
+		// each line terminated by LF.
+		// An error encoding a value is reported by Build.
+		//
+		// If HTTP method is not set or GET or DELETE, the value is set as POST.
+		//
+		// If Content-Type header is not set, then the value is set as application/x-ndjson.
+		SetNDJSON(values ...interface{}) RequestBuilder
+		// SetNDJSONStream sets the request body to the values of the iterator encoded as JSON lines
+		// while the body is read, like SetNDJSON. Each built request iterates over the values again.
+		// NDJSONValues returns an iterator over a list of values.
+		//
+		// An error encoding a value is returned by the body's Read.
+		// Closing the body stops the iteration.
+		SetNDJSONStream(seq func(yield func(interface{}) bool), opts ...NDJSONOption) RequestBuilder
+		// SetNDJSONChan sets the request body to the values received from the channel,
+		// like SetNDJSONStream. The body can be read only once.
+		SetNDJSONChan(ch <-chan interface{}, opts ...NDJSONOption) RequestBuilder
+		// SetBodyFromFile sets the file's content, e.g. testdata/book.json, to the request body.
+		// An error reading the file is reported by Build.
+		//
+		// If HTTP method is not set or GET or DELETE, the value is set as POST.
+		//
+		// If Content-Type header is not set, then the value is set as the MIME type
+		// of the file's extension, or application/octet-stream if it is unknown.
+		SetBodyFromFile(name string) RequestBuilder
+		// SetBodyFromFS sets the content of the fsys's file, e.g. of an embed.FS,
+		// to the request body like SetBodyFromFile.
+		SetBodyFromFS(fsys fs.FS, name string) RequestBuilder
+		// SetBodyTemplate renders the text/template with the data and sets the result to the request body.
+		// An error parsing or executing the template is reported by Build.
+		//
+		// If HTTP method is not set or GET or DELETE, the value is set as POST.
+		// Content-Type header is not changed.
+		SetBodyTemplate(tmpl string, data interface{}) RequestBuilder
+		// SetContentEncoding encodes the request body, set by any other setter, in the content codings
+		// and sets the Content-Encoding header. Stacked codings are applied in the order listed,
+		// e.g. gzip, deflate, as in the header. See RegisterEncoder for custom codings.
+		//
+		// The body is read into memory and encoded when the request is built.
+		// A missing encoder is reported by Build.
+		SetContentEncoding(codings ...string) RequestBuilder
+		// CorruptContentEncoding truncates the encoded request body, set by SetContentEncoding,
+		// so decoding it fails, for testing decompression error handling.
+		CorruptContentEncoding() RequestBuilder
 		// SetAuth sets the request's Authorization header.
 		// Prefix specifies the authentication scheme.
 		SetAuth(prefix, value string) RequestBuilder
@@ -78,29 +329,83 @@ type (
 		SetBearerAuth(token string) RequestBuilder
 		// SetUserAgent sets User-Agent header.
 		SetUserAgent(value string) RequestBuilder
+		// Clone returns a deep copy of the builder. Changes made to the copy
+		// never affect the original builder and vice versa.
+		//
+		// The body set with SetBody is read into memory and both builders receive
+		// their own reader over the same data. A FaultReader body is not read,
+		// and the copy reports an error instead.
+		Clone() RequestBuilder
 		// Request constructs and returns a new incoming server http.Request for testing.
 		//
 		// If PostForm is initialized, the request body will be set as strings.Reader of its values.
-		// Other method SetBody calls will be ignored.
+		// Likewise, if multipart fields or files are set, the request body will be set as
+		// the encoded multipart form. Other method SetBody calls will be ignored.
+		//
+		// The request's GetBody is set if the body is set by SetBodyBytes, SetBodyString,
+		// SetBody with an in-memory reader, SetJSON, SetJSONFromValue, SetPostForm or SetPostFormValue, and
+		// each call of Request returns a request with its own fully readable body.
+		//
+		// Request panics if the request cannot be built. Use Build or MustBuild to handle the error.
 		Request() *http.Request
+		// RepeatableRequest constructs a new request like Request.
+		// A body set with SetBody is read into memory first, so RepeatableRequest can be called
+		// repeatedly on the same builder, each request having its own fully readable body and GetBody.
+		RepeatableRequest() *http.Request
+		// Build constructs a new incoming server http.Request for testing like Request,
+		// but returns the errors collected by the builder instead of panicking.
+		Build() (*http.Request, error)
+		// MustBuild constructs a new incoming server http.Request for testing like Request.
+		// If the request cannot be built, it calls t.Fatalf.
+		MustBuild(t testing.TB) *http.Request
 	}
 	requestBuilder struct {
-		target   string
-		method   string
-		headers  map[string][]string
-		query    url.Values
-		body     io.Reader
-		postForm url.Values
-		context  context.Context
-		cookies  []*http.Cookie
+		target            string
+		pathTemplate      string
+		pathParams        map[string]string
+		pathValues        map[string]string
+		pathPattern       string
+		method            string
+		proto             string
+		chunked           bool
+		contentLength     int64
+		contentLengthMode contentLengthMode
+		trailer           http.Header
+		host              string
+		remoteAddr        string
+		tls               *tls.ConnectionState
+		withoutTLS        bool
+		clientCerts       []*x509.Certificate
+		headers           http.Header
+		rawKeys           bool
+		query             url.Values
+		rawQuery          string
+		targetQuery       url.Values
+		targetRawQuery    string
+		body              bodySource
+		postForm          url.Values
+		multipart         *multipartForm
+		jsonEdits         []jsonEdit
+		contentEncodings  []string
+		corruptEncoding   bool
+		context           context.Context
+		cookies           []*http.Cookie
+		errs              []error
 	}
+	// buildError reports all errors collected by a RequestBuilder.
+	buildError []error
 )
 
+const baseTarget = "https://server.test"
+
 var (
-	defaultTarget = "https://server.test"
+	defaultTarget = baseTarget
 )
 
-//SetDefaultTarget sets default target for RequestBuilder
+// SetDefaultTarget sets default target for RequestBuilder
+//
+// Deprecated: SetDefaultTarget changes package state and is not safe for parallel tests.
+// Use New with WithTarget instead.
 func SetDefaultTarget(target string) {
 	defaultTarget = target
 }
@@ -108,27 +413,61 @@ func SetDefaultTarget(target string) {
 // Builder returns a new RequestBuilder for constructing a test http.Request.
 // By default HTTP method is set as GET.
 func Builder() RequestBuilder {
-	return &requestBuilder{
-		target:  defaultTarget,
+	return newBuilder(defaultTarget)
+}
+
+func newBuilder(target string) *requestBuilder {
+	b := &requestBuilder{
 		method:  http.MethodGet,
-		headers: map[string][]string{},
+		headers: http.Header{},
 		query:   url.Values{},
 		cookies: []*http.Cookie{},
 	}
+	b.SetTarget(target)
+	return b
 }
 
 func (b *requestBuilder) SetTarget(target string) RequestBuilder {
-	b.target = target
+	b.resetTargetQuery()
+	i := strings.IndexByte(target, '?')
+	if i < 0 {
+		b.target = target
+		return b
+	}
+	b.target = target[:i]
+	q, err := url.ParseQuery(target[i+1:])
+	if err != nil {
+		b.targetRawQuery = target[i+1:]
+		return b.SetRawQuery(target[i+1:])
+	}
+	b.targetQuery = q
+	for key, values := range q {
+		b.query[key] = append([]string(nil), values...)
+	}
 	return b
 }
 
+// resetTargetQuery removes the query parameters and the raw query set by the previous target,
+// unless they were changed since.
+func (b *requestBuilder) resetTargetQuery() {
+	for key, values := range b.targetQuery {
+		if slices.Equal(b.query[key], values) {
+			delete(b.query, key)
+		}
+	}
+	if b.targetRawQuery != "" && b.rawQuery == b.targetRawQuery {
+		b.rawQuery = ""
+	}
+	b.targetQuery, b.targetRawQuery = nil, ""
+}
+
 func (b *requestBuilder) SetMethod(method string) RequestBuilder {
 	b.method = method
 	return b
 }
 
 func (b *requestBuilder) SetQuery(q url.Values) RequestBuilder {
-	b.query = q
+	b.query = cloneValues(q)
 	return b
 }
 
@@ -137,11 +476,58 @@ func (b *requestBuilder) SetQueryValue(key string, value ...string) RequestBuild
 	return b
 }
 
+func (b *requestBuilder) AddQueryValue(key string, value ...string) RequestBuilder {
+	b.query[key] = append(b.query[key], value...)
+	return b
+}
+
+func (b *requestBuilder) SetRawQuery(rawQuery string) RequestBuilder {
+	b.rawQuery = rawQuery
+	return b
+}
+
 func (b *requestBuilder) SetHeader(key string, value ...string) RequestBuilder {
-	b.headers[key] = value
+	b.DelHeader(key)
+	b.headers[b.headerKey(key)] = value
+	return b
+}
+
+func (b *requestBuilder) AddHeader(key string, value ...string) RequestBuilder {
+	key = b.headerKey(key)
+	b.headers[key] = append(b.headers[key], value...)
+	return b
+}
+
+func (b *requestBuilder) DelHeader(key string) RequestBuilder {
+	for k := range b.headers {
+		if strings.EqualFold(k, key) {
+			delete(b.headers, k)
+		}
+	}
+	return b
+}
+
+func (b *requestBuilder) SetRawHeaderKeys(raw bool) RequestBuilder {
+	b.rawKeys = raw
 	return b
 }
 
+func (b *requestBuilder) headerKey(key string) string {
+	if b.rawKeys {
+		return key
+	}
+	return http.CanonicalHeaderKey(key)
+}
+
+func (b *requestBuilder) hasHeader(key string) bool {
+	for k := range b.headers {
+		if strings.EqualFold(k, key) {
+			return true
+		}
+	}
+	return false
+}
+
 func (b *requestBuilder) SetContentType(value string) RequestBuilder {
 	return b.SetHeader("Content-Type", value)
 }
@@ -173,14 +559,28 @@ func (b *requestBuilder) SetContextValue(key, value interface{}) RequestBuilder
 }
 
 func (b *requestBuilder) SetBody(reader io.Reader) RequestBuilder {
-	b.body = reader
+	switch r := reader.(type) {
+	case nil:
+		b.body = nil
+	case *bytes.Buffer:
+		b.body = bytesBody(bytes.Clone(r.Bytes()))
+	case *bytes.Reader:
+		snapshot := *r
+		data, _ := io.ReadAll(&snapshot)
+		b.body = bytesBody(data)
+	case *strings.Reader:
+		snapshot := *r
+		data, _ := io.ReadAll(&snapshot)
+		b.body = bytesBody(data)
+	default:
+		b.body = &readerBody{r: reader}
+	}
 	return b
 }
 
 func (b *requestBuilder) SetPostForm(postForm url.Values) RequestBuilder {
 	b.postForm = postForm
-	_, ok := b.headers["Content-Type"]
-	if !ok {
+	if !b.hasHeader("Content-Type") {
 		v := "application/x-www-form-urlencoded;charset=UTF-8"
 		b.SetContentType(v)
 	}
@@ -196,25 +596,35 @@ func (b *requestBuilder) SetPostFormValue(key string, value ...string) RequestBu
 }
 
 func (b *requestBuilder) SetJSON(data []byte) RequestBuilder {
-	if b.method == http.MethodGet || b.method == http.MethodDelete {
-		b.method = http.MethodPost
-	}
-	_, ok := b.headers["Content-Type"]
-	if !ok {
-		v := "application/json;charset=UTF-8"
-		b.SetContentType(v)
-	}
-	return b.SetBody(bytes.NewReader(data))
+	return b.setPayload(data, "application/json;charset=UTF-8")
 }
 
 func (b *requestBuilder) SetJSONFromValue(v interface{}) RequestBuilder {
 	bts, err := json.Marshal(v)
 	if err != nil {
-		panic(err)
+		return b.addError("SetJSONFromValue", err)
 	}
 	return b.SetJSON(bts)
 }
 
+// setPayload sets the encoded data to the request body.
+// If HTTP method is GET or DELETE, the value is set as POST.
+// If Content-Type header is not set, then the value is set as contentType, unless it is empty.
+func (b *requestBuilder) setPayload(data []byte, contentType string) RequestBuilder {
+	b.setPayloadDefaults(contentType)
+	return b.SetBodyBytes(data)
+}
+
+// setPayloadDefaults sets the method and Content-Type header of setPayload.
+func (b *requestBuilder) setPayloadDefaults(contentType string) {
+	if b.method == http.MethodGet || b.method == http.MethodDelete {
+		b.method = http.MethodPost
+	}
+	if contentType != "" && !b.hasHeader("Content-Type") {
+		b.SetContentType(contentType)
+	}
+}
+
 func (b *requestBuilder) SetAuth(prefix, value string) RequestBuilder {
 	auth := strings.Join([]string{prefix, value}, " ")
 	return b.SetHeader("Authorization", auth)
@@ -233,25 +643,244 @@ func (b *requestBuilder) SetUserAgent(value string) RequestBuilder {
 	return b.SetHeader("User-Agent", value)
 }
 
-func (b *requestBuilder) Request() *http.Request {
-	body := b.body
+func (b *requestBuilder) Clone() RequestBuilder {
+	c := &requestBuilder{
+		target:            b.target,
+		pathTemplate:      b.pathTemplate,
+		pathPattern:       b.pathPattern,
+		method:            b.method,
+		proto:             b.proto,
+		chunked:           b.chunked,
+		contentLength:     b.contentLength,
+		contentLengthMode: b.contentLengthMode,
+		host:              b.host,
+		remoteAddr:        b.remoteAddr,
+		tls:               b.tls,
+		withoutTLS:        b.withoutTLS,
+		clientCerts:       b.clientCerts,
+		query:             cloneValues(b.query),
+		rawQuery:          b.rawQuery,
+		targetQuery:       b.targetQuery,
+		targetRawQuery:    b.targetRawQuery,
+		headers:           make(http.Header, len(b.headers)),
+		rawKeys:           b.rawKeys,
+		jsonEdits:         append([]jsonEdit(nil), b.jsonEdits...),
+		contentEncodings:  b.contentEncodings,
+		corruptEncoding:   b.corruptEncoding,
+		context:           b.context,
+		cookies:           make([]*http.Cookie, 0, len(b.cookies)),
+	}
+	for key, values := range b.headers {
+		c.headers[key] = append([]string(nil), values...)
+	}
+	if b.pathParams != nil {
+		c.pathParams = cloneStrings(b.pathParams)
+	}
+	if b.pathValues != nil {
+		c.pathValues = cloneStrings(b.pathValues)
+	}
+	if b.trailer != nil {
+		c.trailer = b.trailer.Clone()
+	}
 	if b.postForm != nil {
-		body = strings.NewReader(b.postForm.Encode())
+		c.postForm = cloneValues(b.postForm)
+	}
+	if b.multipart != nil {
+		c.multipart = b.multipart.clone()
 	}
-	req := httptest.NewRequest(b.method, b.target, body)
-	if b.query != nil {
-		req.URL.RawQuery = b.query.Encode()
+	for _, cookie := range b.cookies {
+		cc := *cookie
+		c.cookies = append(c.cookies, &cc)
+	}
+	if isFaultBody(b.body) {
+		c.errs = append([]error(nil), b.errs...)
+		return c.addError("SetBody", errFaultBody)
+	}
+	b.bufferBody()
+	c.body = b.body
+	c.errs = append([]error(nil), b.errs...)
+	return c
+}
+
+func (b *requestBuilder) Request() *http.Request {
+	req, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return req
+}
+
+func (b *requestBuilder) MustBuild(t testing.TB) *http.Request {
+	t.Helper()
+	req, err := b.Build()
+	if err != nil {
+		t.Fatalf("failed to build request: %v", err)
+	}
+	return req
+}
+
+func (b *requestBuilder) Build() (*http.Request, error) {
+	if len(b.errs) > 0 {
+		return nil, buildError(b.errs)
+	}
+	source, err := b.source()
+	if err != nil {
+		return nil, err
+	}
+	body, getBody, err := openBody(source)
+	if err != nil {
+		return nil, err
+	}
+	target, err := b.buildTarget()
+	if err != nil {
+		return nil, err
+	}
+	req, err := newRequest(b.method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	req.GetBody = getBody
+	if sized, ok := source.(sizedBody); ok {
+		req.ContentLength = sized.size()
 	}
 	for key, values := range b.headers {
-		for _, value := range values {
-			req.Header.Add(key, value)
-		}
+		req.Header[key] = append(req.Header[key], values...)
+	}
+	if typed, ok := source.(typedBody); ok && !b.hasHeader("Content-Type") {
+		req.Header.Set("Content-Type", typed.contentType())
 	}
 	for _, c := range b.cookies {
 		req.AddCookie(c)
 	}
+	if b.host != "" {
+		req.Host = b.host
+	}
+	if b.remoteAddr != "" {
+		req.RemoteAddr = b.remoteAddr
+	}
+	b.setTLS(req)
+	if err := b.setProto(req); err != nil {
+		return nil, err
+	}
+	if err := b.setContentLength(req); err != nil {
+		return nil, err
+	}
+	if err := b.setTransfer(req); err != nil {
+		return nil, err
+	}
+	if b.pathPattern != "" {
+		if err := MatchPattern(req, b.pathPattern); err != nil {
+			return nil, err
+		}
+	}
+	for name, value := range b.pathValues {
+		req.SetPathValue(name, value)
+	}
+	ctx := req.Context()
 	if b.context != nil {
-		return req.WithContext(b.context)
+		ctx = b.context
 	}
-	return req
+	if b.pathTemplate != "" {
+		ctx = context.WithValue(ctx, pathTemplateKey{}, b.pathTemplate)
+	}
+	if ctx != req.Context() {
+		req = req.WithContext(ctx)
+	}
+	if rb, ok := source.(*readerBody); ok {
+		for _, f := range faultReaders(rb.r) {
+			if f.blockAfter >= 0 && req.Context().Done() == nil {
+				return nil, errors.New("testrequest: BlockAfter: the body would block forever, set a cancellable context with SetContext")
+			}
+			f.bindContext(req.Context())
+		}
+	}
+	return req, nil
+}
+
+func (b *requestBuilder) setTLS(req *http.Request) {
+	if b.withoutTLS {
+		req.TLS = nil
+		return
+	}
+	if b.tls != nil {
+		state := *b.tls
+		req.TLS = &state
+	}
+	if b.clientCerts == nil {
+		return
+	}
+	if req.TLS == nil {
+		req.TLS = &tls.ConnectionState{
+			Version:           tls.VersionTLS12,
+			HandshakeComplete: true,
+			ServerName:        req.Host,
+		}
+	}
+	req.TLS.PeerCertificates = b.clientCerts
+	req.TLS.VerifiedChains = [][]*x509.Certificate{b.clientCerts}
+}
+
+func (b *requestBuilder) buildTarget() (string, error) {
+	target := b.target
+	if b.pathTemplate != "" {
+		path, err := expandPath(b.pathTemplate, b.pathParams)
+		if err != nil {
+			return "", fmt.Errorf("testrequest: SetPathTemplate: %w", err)
+		}
+		target = strings.TrimSuffix(target, "/") + path
+	}
+	query := b.rawQuery
+	if query == "" {
+		query = b.query.Encode()
+	}
+	if query == "" {
+		return target, nil
+	}
+	return target + "?" + query, nil
+}
+
+func (b *requestBuilder) addError(method string, err error) RequestBuilder {
+	b.errs = append(b.errs, fmt.Errorf("testrequest: %s: %w", method, err))
+	return b
+}
+
+// newRequest wraps httptest.NewRequest, which panics on invalid arguments.
+func newRequest(method, target string, body io.Reader) (req *http.Request, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("testrequest: %v", r)
+		}
+	}()
+	return httptest.NewRequest(method, target, body), nil
+}
+
+func (e buildError) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e buildError) Unwrap() []error {
+	return e
+}
+
+func cloneValues(values url.Values) url.Values {
+	c := make(url.Values, len(values))
+	for key, v := range values {
+		c[key] = append([]string(nil), v...)
+	}
+	return c
+}
+
+func cloneStrings(m map[string]string) map[string]string {
+	c := make(map[string]string, len(m))
+	for key, value := range m {
+		c[key] = value
+	}
+	return c
 }
@@ -4,7 +4,8 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/url"
@@ -277,3 +278,319 @@ func Test_requestBuilder_SetJSONFromValue(t *testing.T) {
 		t.Errorf("SetJSONFromValue() = %v, want %v", got, want)
 	}
 }
+
+func Test_requestBuilder_Clone(t *testing.T) {
+	parent := Builder().
+		SetHeader("X-Test", "parent").
+		SetQueryValue("test", "parent").
+		SetPostFormValue("test", "parent").
+		SetCookies(&http.Cookie{Name: "test", Value: "parent"})
+	parent.Clone().
+		SetHeader("X-Test", "clone").
+		SetQueryValue("test", "clone").
+		SetPostFormValue("test", "clone").
+		SetCookies(&http.Cookie{Name: "test", Value: "clone"}).
+		SetMethod(http.MethodPut).
+		Request()
+	req := parent.Request()
+	if got := req.Header.Get("X-Test"); got != "parent" {
+		t.Errorf("Clone() parentHeader = %v, want %v", got, "parent")
+	}
+	if got := req.URL.Query().Get("test"); got != "parent" {
+		t.Errorf("Clone() parentQuery = %v, want %v", got, "parent")
+	}
+	req.ParseForm()
+	if got := req.PostForm.Get("test"); got != "parent" {
+		t.Errorf("Clone() parentPostForm = %v, want %v", got, "parent")
+	}
+	if c, err := req.Cookie("test"); err != nil || c.Value != "parent" {
+		t.Errorf("Clone() parentCookie = %v, want %v", c, "parent")
+	}
+	if req.Method != http.MethodPost {
+		t.Errorf("Clone() parentMethod = %v, want %v", req.Method, http.MethodPost)
+	}
+}
+
+func Test_requestBuilder_CloneSharedValues(t *testing.T) {
+	header := []string{"parent"}
+	cookie := &http.Cookie{Name: "test", Value: "parent"}
+	parent := Builder().SetHeader("X-Test", header...).SetCookies(cookie)
+	clone := parent.Clone()
+	clone.(*requestBuilder).headers["X-Test"][0] = "clone"
+	clone.(*requestBuilder).cookies[0].Value = "clone"
+	req := parent.Request()
+	if got := req.Header.Get("X-Test"); got != "parent" {
+		t.Errorf("Clone() parentHeader = %v, want %v", got, "parent")
+	}
+	if got := req.Cookies()[0].Value; got != "parent" {
+		t.Errorf("Clone() parentCookie = %v, want %v", got, "parent")
+	}
+}
+
+func Test_requestBuilder_CloneContext(t *testing.T) {
+	parent := Builder().SetContextValue("parent", "parent")
+	clone := parent.Clone().SetContextValue("clone", "clone")
+	if got := parent.Request().Context().Value("clone"); got != nil {
+		t.Errorf("Clone() parentContextValue = %v, want %v", got, nil)
+	}
+	if got := clone.Request().Context().Value("parent"); got != "parent" {
+		t.Errorf("Clone() cloneContextValue = %v, want %v", got, "parent")
+	}
+}
+
+func Test_requestBuilder_CloneBody(t *testing.T) {
+	want := []byte("test")
+	parent := Builder().SetBody(io.MultiReader(bytes.NewReader(want)))
+	clone := parent.Clone()
+	got, _ := io.ReadAll(clone.Request().Body)
+	if !bytes.Equal(got, want) {
+		t.Errorf("Clone() cloneBody = %v, want %v", got, want)
+	}
+	req, err := parent.Build()
+	if err != nil {
+		t.Fatalf("Clone() parent error = %v", err)
+	}
+	got, _ = io.ReadAll(req.Body)
+	if !bytes.Equal(got, want) {
+		t.Errorf("Clone() parentBody = %v, want %v", got, want)
+	}
+}
+
+func Test_requestBuilder_Build(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder RequestBuilder
+		wantErr bool
+	}{
+		{
+			name:    "Success",
+			builder: Builder().SetJSONFromValue(map[string]string{"test": "test"}),
+			wantErr: false,
+		},
+		{
+			name:    "InvalidJSONValue",
+			builder: Builder().SetJSONFromValue(make(chan int)),
+			wantErr: true,
+		},
+		{
+			name:    "InvalidTarget",
+			builder: Builder().SetTarget("http://[::1"),
+			wantErr: true,
+		},
+		{
+			name:    "InvalidMethod",
+			builder: Builder().SetMethod("BAD METHOD"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.builder.Build()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Build() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if (got == nil) != tt.wantErr {
+				t.Errorf("Build() = %v, wantErr %v", got, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_requestBuilder_BuildErrors(t *testing.T) {
+	_, err := Builder().
+		SetJSONFromValue(make(chan int)).
+		SetJSONFromValue(func() {}).
+		Build()
+	var jsonErr *json.UnsupportedTypeError
+	if !errors.As(err, &jsonErr) {
+		t.Errorf("Build() error = %v, want %T", err, jsonErr)
+		return
+	}
+	if got := len(err.(buildError)); got != 2 {
+		t.Errorf("Build() errors = %v, want %v", got, 2)
+	}
+}
+
+type fatalRecorder struct {
+	testing.TB
+	msg string
+}
+
+func (r *fatalRecorder) Helper() {}
+
+func (r *fatalRecorder) Fatalf(format string, args ...interface{}) {
+	r.msg = fmt.Sprintf(format, args...)
+}
+
+func Test_requestBuilder_MustBuild(t *testing.T) {
+	r := &fatalRecorder{TB: t}
+	got := Builder().SetJSONFromValue(make(chan int)).MustBuild(r)
+	if got != nil {
+		t.Errorf("MustBuild() = %v, want %v", got, nil)
+	}
+	if !strings.Contains(r.msg, "SetJSONFromValue") {
+		t.Errorf("MustBuild() message = %v, want SetJSONFromValue error", r.msg)
+	}
+}
+
+func Test_requestBuilder_RequestPanics(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Request() did not panic")
+		}
+	}()
+	Builder().SetJSONFromValue(make(chan int)).Request()
+}
+
+func Test_requestBuilder_SetTargetQuery(t *testing.T) {
+	tests := []struct {
+		name         string
+		builder      RequestBuilder
+		wantRawQuery string
+	}{
+		{
+			name:         "TargetQuery",
+			builder:      Builder().SetTarget("https://server.test/books?page=2"),
+			wantRawQuery: "page=2",
+		},
+		{
+			name:         "MergedQuery",
+			builder:      Builder().SetTarget("https://server.test/books?page=2&size=10").SetQueryValue("size", "20"),
+			wantRawQuery: "page=2&size=20",
+		},
+		{
+			name:         "QueryBeforeTarget",
+			builder:      Builder().SetQueryValue("size", "20").SetTarget("https://server.test/books?page=2"),
+			wantRawQuery: "page=2&size=20",
+		},
+		{
+			name:         "ReplacedQuery",
+			builder:      Builder().SetTarget("https://server.test/books?page=2").SetQuery(url.Values{"size": {"20"}}),
+			wantRawQuery: "size=20",
+		},
+		{
+			name:         "AddedQueryValue",
+			builder:      Builder().SetTarget("https://server.test/books?tag=go").AddQueryValue("tag", "http"),
+			wantRawQuery: "tag=go&tag=http",
+		},
+		{
+			name:         "UnparsableTargetQuery",
+			builder:      Builder().SetTarget("https://server.test/books?a=1;b=2"),
+			wantRawQuery: "a=1;b=2",
+		},
+		{
+			name:         "ReplacedTargetQuery",
+			builder:      Builder().SetTarget("/authors?x=1").SetTarget("https://server.test/books?page=2"),
+			wantRawQuery: "page=2",
+		},
+		{
+			name:         "ChangedTargetQuery",
+			builder:      Builder().SetTarget("/authors?x=1").SetQueryValue("x", "2").SetTarget("https://server.test/books?page=2"),
+			wantRawQuery: "page=2&x=2",
+		},
+		{
+			name:         "ReplacedUnparsableTargetQuery",
+			builder:      Builder().SetTarget("/authors?a=1;b=2").SetTarget("https://server.test/books?page=2"),
+			wantRawQuery: "page=2",
+		},
+		{
+			name:         "FactoryTargetQuery",
+			builder:      New(WithTarget("https://server.test/authors?k=v")).Builder().SetTarget("https://server.test/books?page=2"),
+			wantRawQuery: "page=2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := tt.builder.Request()
+			if req.URL.RawQuery != tt.wantRawQuery {
+				t.Errorf("SetTarget() rawQuery = %v, want %v", req.URL.RawQuery, tt.wantRawQuery)
+			}
+			if want := "/books?" + tt.wantRawQuery; req.URL.RequestURI() != want {
+				t.Errorf("SetTarget() requestURI = %v, want %v", req.URL.RequestURI(), want)
+			}
+		})
+	}
+}
+
+func Test_requestBuilder_SetTargetWithoutQuery(t *testing.T) {
+	req := Builder().SetTarget("/authors?x=1").SetTarget("/books").Request()
+	if got := req.URL.RequestURI(); got != "/books" {
+		t.Errorf("SetTarget() requestURI = %v, want %v", got, "/books")
+	}
+}
+
+func Test_requestBuilder_AddQueryValue(t *testing.T) {
+	req := Builder().
+		SetQueryValue("test", "test1").
+		AddQueryValue("test", "test2", "test3").
+		Request()
+	got := req.URL.Query()
+	want := url.Values{"test": {"test1", "test2", "test3"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("AddQueryValue() = %v, wantQuery%v", got, want)
+	}
+}
+
+func Test_requestBuilder_SetRawQuery(t *testing.T) {
+	want := "b=2&a=1+1&b=%201"
+	req := Builder().
+		SetQueryValue("test", "test").
+		SetRawQuery(want).
+		Request()
+	if got := req.URL.RawQuery; got != want {
+		t.Errorf("SetRawQuery() = %v, want %v", got, want)
+	}
+}
+
+func Test_requestBuilder_AddHeader(t *testing.T) {
+	req := Builder().
+		SetHeader("x-test", "test1").
+		AddHeader("X-TEST", "test2").
+		Request()
+	want := http.Header{"X-Test": {"test1", "test2"}}
+	if got := req.Header; !reflect.DeepEqual(got, want) {
+		t.Errorf("AddHeader() = %v, wantHeader %v", got, want)
+	}
+}
+
+func Test_requestBuilder_DelHeader(t *testing.T) {
+	req := Builder().
+		SetRawHeaderKeys(true).
+		SetHeader("X-Test", "test").
+		AddHeader("x-test", "test").
+		SetHeader("X-Other", "test").
+		DelHeader("X-TEST").
+		Request()
+	want := http.Header{"X-Other": {"test"}}
+	if got := req.Header; !reflect.DeepEqual(got, want) {
+		t.Errorf("DelHeader() = %v, wantHeader %v", got, want)
+	}
+}
+
+func Test_requestBuilder_SetHeaderCanonicalKey(t *testing.T) {
+	req := Builder().
+		SetHeader("content-type", "application/json").
+		SetJSON([]byte("{}")).
+		Request()
+	want := []string{"application/json"}
+	if got := req.Header["Content-Type"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("SetHeader() = %v, want %v", got, want)
+	}
+}
+
+func Test_requestBuilder_SetRawHeaderKeys(t *testing.T) {
+	req := Builder().
+		SetRawHeaderKeys(true).
+		SetHeader("x-request-id", "test").
+		SetHeader("content-type", "application/json").
+		SetJSON([]byte("{}")).
+		Request()
+	want := http.Header{
+		"x-request-id": {"test"},
+		"content-type": {"application/json"},
+	}
+	if got := req.Header; !reflect.DeepEqual(got, want) {
+		t.Errorf("SetRawHeaderKeys() = %v, wantHeader %v", got, want)
+	}
+}
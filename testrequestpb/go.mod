@@ -0,0 +1,11 @@
+module github.com/redagain/go-testrequest/testrequestpb
+
+go 1.22
+
+require (
+	github.com/redagain/go-testrequest v0.0.0
+	google.golang.org/protobuf v1.34.2
+)
+
+// Until the root module is tagged, the subpackage is built with the root module of this repository.
+replace github.com/redagain/go-testrequest => ../
@@ -0,0 +1,97 @@
+// Package testrequestpb provides Protocol Buffers request bodies for testrequest.RequestBuilder.
+//
+// Importing the package registers codecs for application/x-protobuf and application/protobuf,
+// so messages can also be set by RequestBuilder.SetBodyFromValue.
+package testrequestpb
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/redagain/go-testrequest"
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// MediaType is the media type of binary Protocol Buffers messages.
+	MediaType = "application/x-protobuf"
+	// StandardMediaType is the application/protobuf media type of binary Protocol Buffers messages.
+	StandardMediaType = "application/protobuf"
+	// StreamMediaType is the media type of size-delimited binary Protocol Buffers messages.
+	StreamMediaType = "application/x-protobuf;delimited=true"
+)
+
+type (
+	binaryCodec    string
+	jsonCodec      struct{}
+	delimitedCodec struct{}
+)
+
+func init() {
+	testrequest.RegisterCodec(binaryCodec(MediaType))
+	testrequest.RegisterCodec(binaryCodec(StandardMediaType))
+}
+
+// SetProto sets the binary encoded message to the request body with the application/x-protobuf
+// Content-Type, applying the defaults of RequestBuilder.SetBodyFromValue.
+// An error encoding the message is reported by RequestBuilder.Build.
+func SetProto(b testrequest.RequestBuilder, msg proto.Message) testrequest.RequestBuilder {
+	return b.SetBodyWithCodec(binaryCodec(MediaType), msg)
+}
+
+// SetProtoJSON sets the message encoded by protojson to the request body
+// with the application/json Content-Type, like SetProto.
+func SetProtoJSON(b testrequest.RequestBuilder, msg proto.Message) testrequest.RequestBuilder {
+	return b.SetBodyWithCodec(jsonCodec{}, msg)
+}
+
+// SetProtoStream sets the messages to the request body as a stream of size-delimited
+// binary encoded messages, as written by protodelim.MarshalTo, like SetProto.
+// The Content-Type is application/x-protobuf;delimited=true.
+func SetProtoStream(b testrequest.RequestBuilder, msgs ...proto.Message) testrequest.RequestBuilder {
+	return b.SetBodyWithCodec(delimitedCodec{}, msgs)
+}
+
+func (c binaryCodec) MediaType() string {
+	return string(c)
+}
+
+func (c binaryCodec) Marshal(v interface{}) ([]byte, error) {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("testrequestpb: %T is not a proto.Message", v)
+	}
+	return proto.Marshal(msg)
+}
+
+func (jsonCodec) MediaType() string {
+	return "application/json"
+}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("testrequestpb: %T is not a proto.Message", v)
+	}
+	return protojson.Marshal(msg)
+}
+
+func (delimitedCodec) MediaType() string {
+	return StreamMediaType
+}
+
+func (delimitedCodec) Marshal(v interface{}) ([]byte, error) {
+	msgs, ok := v.([]proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("testrequestpb: %T is not a []proto.Message", v)
+	}
+	var buf bytes.Buffer
+	for _, msg := range msgs {
+		if _, err := protodelim.MarshalTo(&buf, msg); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,77 @@
+package testrequestpb
+
+import (
+	"bufio"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/redagain/go-testrequest"
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestSetProto(t *testing.T) {
+	want := wrapperspb.String("test")
+	req := SetProto(testrequest.Builder(), want).Request()
+	if req.Method != http.MethodPost {
+		t.Errorf("SetProto() method = %v, want %v", req.Method, http.MethodPost)
+	}
+	if got := req.Header.Get("Content-Type"); got != MediaType {
+		t.Errorf("SetProto() contentType = %v, want %v", got, MediaType)
+	}
+	data, _ := io.ReadAll(req.Body)
+	got := &wrapperspb.StringValue{}
+	if err := proto.Unmarshal(data, got); err != nil || !proto.Equal(got, want) {
+		t.Errorf("SetProto() = %v, %v, want %v", got, err, want)
+	}
+}
+
+func TestSetBodyFromValue(t *testing.T) {
+	want := wrapperspb.Int64(42)
+	req := testrequest.Builder().SetBodyFromValue(StandardMediaType, want).Request()
+	if got := req.Header.Get("Content-Type"); got != StandardMediaType {
+		t.Errorf("SetBodyFromValue() contentType = %v, want %v", got, StandardMediaType)
+	}
+	data, _ := io.ReadAll(req.Body)
+	got := &wrapperspb.Int64Value{}
+	if err := proto.Unmarshal(data, got); err != nil || !proto.Equal(got, want) {
+		t.Errorf("SetBodyFromValue() = %v, %v, want %v", got, err, want)
+	}
+	if _, err := testrequest.Builder().SetBodyFromValue(MediaType, "test").Build(); err == nil {
+		t.Errorf("SetBodyFromValue() error = %v, wantErr %v", err, true)
+	}
+}
+
+func TestSetProtoJSON(t *testing.T) {
+	want := wrapperspb.String("test")
+	req := SetProtoJSON(testrequest.Builder(), want).Request()
+	if got := req.Header.Get("Content-Type"); got != "application/json" {
+		t.Errorf("SetProtoJSON() contentType = %v, want %v", got, "application/json")
+	}
+	data, _ := io.ReadAll(req.Body)
+	got := &wrapperspb.StringValue{}
+	if err := protojson.Unmarshal(data, got); err != nil || !proto.Equal(got, want) {
+		t.Errorf("SetProtoJSON() = %v, %v, want %v", got, err, want)
+	}
+}
+
+func TestSetProtoStream(t *testing.T) {
+	want := []proto.Message{wrapperspb.String("first"), wrapperspb.String("second")}
+	req := SetProtoStream(testrequest.Builder(), want...).Request()
+	if got := req.Header.Get("Content-Type"); got != StreamMediaType {
+		t.Errorf("SetProtoStream() contentType = %v, want %v", got, StreamMediaType)
+	}
+	r := bufio.NewReader(req.Body)
+	for _, w := range want {
+		got := &wrapperspb.StringValue{}
+		if err := protodelim.UnmarshalFrom(r, got); err != nil || !proto.Equal(got, w) {
+			t.Errorf("SetProtoStream() = %v, %v, want %v", got, err, w)
+		}
+	}
+	if _, err := r.ReadByte(); err != io.EOF {
+		t.Errorf("SetProtoStream() trailing data, err = %v", err)
+	}
+}
@@ -0,0 +1,97 @@
+package testrequest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+func (b *requestBuilder) SetTLS(state *tls.ConnectionState) RequestBuilder {
+	b.tls = state
+	b.withoutTLS = false
+	return b
+}
+
+func (b *requestBuilder) WithoutTLS() RequestBuilder {
+	b.tls = nil
+	b.clientCerts = nil
+	b.withoutTLS = true
+	return b
+}
+
+func (b *requestBuilder) SetClientCertificate(subject pkix.Name, sans ...string) RequestBuilder {
+	chain, err := clientCertificateChain(subject, sans)
+	if err != nil {
+		return b.addError("SetClientCertificate", err)
+	}
+	b.clientCerts = chain
+	b.withoutTLS = false
+	return b
+}
+
+// clientCertificateChain generates a client certificate signed by a self-signed CA.
+// The chain starts with the client certificate and ends with the CA certificate.
+func clientCertificateChain(subject pkix.Name, sans []string) ([]*x509.Certificate, error) {
+	now := time.Now()
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "testrequest CA"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		return nil, err
+	}
+	ca, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		return nil, err
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      subject,
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	for _, san := range sans {
+		if ip := net.ParseIP(san); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else if u, err := url.Parse(san); err == nil && u.Scheme != "" && strings.Contains(san, "://") {
+			template.URIs = append(template.URIs, u)
+		} else if strings.Contains(san, "@") {
+			template.EmailAddresses = append(template.EmailAddresses, san)
+		} else {
+			template.DNSNames = append(template.DNSNames, san)
+		}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+	return []*x509.Certificate{cert, ca}, nil
+}
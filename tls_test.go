@@ -0,0 +1,70 @@
+package testrequest
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+)
+
+func Test_requestBuilder_SetTLS(t *testing.T) {
+	state := &tls.ConnectionState{
+		Version:            tls.VersionTLS13,
+		HandshakeComplete:  true,
+		NegotiatedProtocol: "h2",
+		ServerName:         "books.test",
+	}
+	req := Builder().SetTarget("http://server.test").SetTLS(state).Request()
+	if req.TLS == nil {
+		t.Errorf("SetTLS() = %v, want %v", req.TLS, state)
+		return
+	}
+	if req.TLS == state {
+		t.Errorf("SetTLS() state is shared with the builder")
+	}
+	if req.TLS.Version != tls.VersionTLS13 || req.TLS.NegotiatedProtocol != "h2" || req.TLS.ServerName != "books.test" {
+		t.Errorf("SetTLS() = %v, want %v", req.TLS, state)
+	}
+}
+
+func Test_requestBuilder_WithoutTLS(t *testing.T) {
+	req := Builder().
+		SetTarget("https://server.test").
+		SetClientCertificate(pkix.Name{CommonName: "test"}).
+		WithoutTLS().
+		Request()
+	if req.TLS != nil {
+		t.Errorf("WithoutTLS() = %v, want %v", req.TLS, nil)
+	}
+}
+
+func Test_requestBuilder_SetClientCertificate(t *testing.T) {
+	req := Builder().
+		SetTarget("http://server.test").
+		SetClientCertificate(pkix.Name{CommonName: "client", Organization: []string{"books"}},
+			"client.books.test", "192.0.2.1", "spiffe://books.test/client", "client@books.test").
+		Request()
+	if req.TLS == nil || len(req.TLS.PeerCertificates) != 2 {
+		t.Errorf("SetClientCertificate() = %v, want chain of 2 certificates", req.TLS)
+		return
+	}
+	cert := req.TLS.PeerCertificates[0]
+	if cert.Subject.CommonName != "client" || cert.Subject.Organization[0] != "books" {
+		t.Errorf("SetClientCertificate() subject = %v", cert.Subject)
+	}
+	if len(cert.DNSNames) != 1 || len(cert.IPAddresses) != 1 || len(cert.URIs) != 1 || len(cert.EmailAddresses) != 1 {
+		t.Errorf("SetClientCertificate() sans = %v %v %v %v", cert.DNSNames, cert.IPAddresses, cert.URIs, cert.EmailAddresses)
+	}
+	roots := x509.NewCertPool()
+	roots.AddCert(req.TLS.PeerCertificates[1])
+	_, err := cert.Verify(x509.VerifyOptions{
+		Roots:     roots,
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	if err != nil {
+		t.Errorf("SetClientCertificate() verify error = %v", err)
+	}
+	if len(req.TLS.VerifiedChains) != 1 {
+		t.Errorf("SetClientCertificate() verifiedChains = %v, want 1", len(req.TLS.VerifiedChains))
+	}
+}
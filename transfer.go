@@ -0,0 +1,71 @@
+package testrequest
+
+import (
+	"errors"
+	"io"
+	"net/http"
+)
+
+// trailerReader fills the request's trailer once the body reaches EOF,
+// the way net/http server does for chunked requests.
+type trailerReader struct {
+	io.ReadCloser
+	trailer http.Header
+	values  http.Header
+}
+
+func (b *requestBuilder) SetChunked() RequestBuilder {
+	b.chunked = true
+	return b
+}
+
+func (b *requestBuilder) SetTrailer(key string, value ...string) RequestBuilder {
+	if b.trailer == nil {
+		b.trailer = http.Header{}
+	}
+	b.trailer[http.CanonicalHeaderKey(key)] = value
+	return b.SetChunked()
+}
+
+// setTransfer sets the request's transfer encoding and trailer.
+func (b *requestBuilder) setTransfer(req *http.Request) error {
+	if !b.chunked {
+		return nil
+	}
+	if req.ProtoMajor == 1 && req.ProtoMinor == 0 {
+		return errors.New("testrequest: SetChunked: chunked transfer encoding is not supported by HTTP/1.0")
+	}
+	if req.ProtoMajor == 1 {
+		req.TransferEncoding = []string{"chunked"}
+	}
+	req.ContentLength = -1
+	if b.trailer == nil {
+		return nil
+	}
+	req.Trailer = make(http.Header, len(b.trailer))
+	for key := range b.trailer {
+		req.Trailer[key] = nil
+	}
+	values := b.trailer.Clone()
+	req.Body = &trailerReader{ReadCloser: req.Body, trailer: req.Trailer, values: values}
+	if getBody := req.GetBody; getBody != nil {
+		req.GetBody = func() (io.ReadCloser, error) {
+			body, err := getBody()
+			if err != nil {
+				return nil, err
+			}
+			return &trailerReader{ReadCloser: body, trailer: req.Trailer, values: values}, nil
+		}
+	}
+	return nil
+}
+
+func (r *trailerReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if err == io.EOF {
+		for key, values := range r.values {
+			r.trailer[key] = values
+		}
+	}
+	return n, err
+}
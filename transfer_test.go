@@ -0,0 +1,76 @@
+package testrequest
+
+import (
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_requestBuilder_SetChunked(t *testing.T) {
+	req := Builder().SetBody(strings.NewReader("test")).SetChunked().Request()
+	if !reflect.DeepEqual(req.TransferEncoding, []string{"chunked"}) {
+		t.Errorf("SetChunked() transferEncoding = %v, want %v", req.TransferEncoding, []string{"chunked"})
+	}
+	if req.ContentLength != -1 {
+		t.Errorf("SetChunked() contentLength = %v, want %v", req.ContentLength, -1)
+	}
+}
+
+func Test_requestBuilder_SetChunkedProto(t *testing.T) {
+	req := Builder().SetBody(strings.NewReader("test")).SetChunked().HTTP2().Request()
+	if req.TransferEncoding != nil || req.ContentLength != -1 {
+		t.Errorf("SetChunked() = %v %v, want %v %v", req.TransferEncoding, req.ContentLength, nil, -1)
+	}
+	_, err := Builder().SetChunked().HTTP10().Build()
+	if err == nil {
+		t.Errorf("SetChunked() error = %v, wantErr %v", err, true)
+	}
+}
+
+func Test_requestBuilder_SetTrailer(t *testing.T) {
+	req := Builder().
+		SetBody(strings.NewReader("test")).
+		SetTrailer("x-checksum", "test").
+		Request()
+	if !reflect.DeepEqual(req.TransferEncoding, []string{"chunked"}) {
+		t.Errorf("SetTrailer() transferEncoding = %v, want %v", req.TransferEncoding, []string{"chunked"})
+	}
+	want := http.Header{"X-Checksum": nil}
+	if !reflect.DeepEqual(req.Trailer, want) {
+		t.Errorf("SetTrailer() before EOF = %v, want %v", req.Trailer, want)
+	}
+	io.ReadAll(req.Body)
+	want = http.Header{"X-Checksum": {"test"}}
+	if !reflect.DeepEqual(req.Trailer, want) {
+		t.Errorf("SetTrailer() after EOF = %v, want %v", req.Trailer, want)
+	}
+}
+
+func Test_requestBuilder_SetTrailerGetBody(t *testing.T) {
+	req := Builder().SetBodyString("test").SetTrailer("x-checksum", "test").Request()
+	body, err := req.GetBody()
+	if err != nil {
+		t.Fatalf("GetBody() error = %v", err)
+	}
+	got, _ := io.ReadAll(body)
+	if string(got) != "test" {
+		t.Errorf("GetBody() = %v, want %v", string(got), "test")
+	}
+	want := http.Header{"X-Checksum": {"test"}}
+	if !reflect.DeepEqual(req.Trailer, want) {
+		t.Errorf("SetTrailer() after GetBody EOF = %v, want %v", req.Trailer, want)
+	}
+}
+
+func Test_requestBuilder_SetTrailerAfterRequest(t *testing.T) {
+	b := Builder().SetBodyString("test").SetTrailer("x-checksum", "test")
+	req := b.Request()
+	b.SetTrailer("x-checksum", "changed")
+	io.ReadAll(req.Body)
+	want := http.Header{"X-Checksum": {"test"}}
+	if !reflect.DeepEqual(req.Trailer, want) {
+		t.Errorf("SetTrailer() after Request = %v, want %v", req.Trailer, want)
+	}
+}
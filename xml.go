@@ -0,0 +1,50 @@
+package testrequest
+
+import (
+	"encoding/xml"
+)
+
+type (
+	// An XMLOption configures the XML request body.
+	XMLOption  func(o *xmlOptions)
+	xmlOptions struct {
+		header  bool
+		charset string
+	}
+)
+
+// WithXMLHeader prepends the XML declaration, e.g. <?xml version="1.0" encoding="UTF-8"?>,
+// to the request body. The encoding is the charset, see WithXMLCharset.
+func WithXMLHeader() XMLOption {
+	return func(o *xmlOptions) {
+		o.header = true
+	}
+}
+
+// WithXMLCharset sets the charset of the Content-Type header and the XML declaration.
+// By default it is UTF-8. The data is not transcoded.
+func WithXMLCharset(charset string) XMLOption {
+	return func(o *xmlOptions) {
+		o.charset = charset
+	}
+}
+
+func (b *requestBuilder) SetXML(data []byte, opts ...XMLOption) RequestBuilder {
+	o := &xmlOptions{charset: "UTF-8"}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.header {
+		declaration := `<?xml version="1.0" encoding="` + o.charset + `"?>` + "\n"
+		data = append([]byte(declaration), data...)
+	}
+	return b.setPayload(data, "application/xml;charset="+o.charset)
+}
+
+func (b *requestBuilder) SetXMLFromValue(v interface{}, opts ...XMLOption) RequestBuilder {
+	bts, err := xml.Marshal(v)
+	if err != nil {
+		return b.addError("SetXMLFromValue", err)
+	}
+	return b.SetXML(bts, opts...)
+}
@@ -0,0 +1,99 @@
+package testrequest
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+type xmlBook struct {
+	XMLName xml.Name `xml:"book"`
+	Title   string   `xml:"title"`
+}
+
+func Test_requestBuilder_SetXML(t *testing.T) {
+	tests := []struct {
+		name            string
+		builder         RequestBuilder
+		wantMethod      string
+		wantContentType string
+		wantBody        string
+		wantErr         bool
+	}{
+		{
+			name:            "Data",
+			builder:         Builder().SetXML([]byte("<book/>")),
+			wantMethod:      http.MethodPost,
+			wantContentType: "application/xml;charset=UTF-8",
+			wantBody:        "<book/>",
+		},
+		{
+			name:            "Header",
+			builder:         Builder().SetMethod(http.MethodPut).SetXML([]byte("<book/>"), WithXMLHeader()),
+			wantMethod:      http.MethodPut,
+			wantContentType: "application/xml;charset=UTF-8",
+			wantBody:        xml.Header + "<book/>",
+		},
+		{
+			name:            "Charset",
+			builder:         Builder().SetXML([]byte("<book/>"), WithXMLHeader(), WithXMLCharset("ISO-8859-1")),
+			wantMethod:      http.MethodPost,
+			wantContentType: "application/xml;charset=ISO-8859-1",
+			wantBody:        `<?xml version="1.0" encoding="ISO-8859-1"?>` + "\n<book/>",
+		},
+		{
+			name:            "PresetContentType",
+			builder:         Builder().SetContentType("text/xml").SetXML([]byte("<book/>")),
+			wantMethod:      http.MethodPost,
+			wantContentType: "text/xml",
+			wantBody:        "<book/>",
+		},
+		{
+			name:            "Value",
+			builder:         Builder().SetXMLFromValue(xmlBook{Title: "test"}),
+			wantMethod:      http.MethodPost,
+			wantContentType: "application/xml;charset=UTF-8",
+			wantBody:        "<book><title>test</title></book>",
+		},
+		{
+			name:    "InvalidValue",
+			builder: Builder().SetXMLFromValue(make(chan int)),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := tt.builder.Build()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SetXML() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if req.Method != tt.wantMethod {
+				t.Errorf("SetXML() method = %v, want %v", req.Method, tt.wantMethod)
+			}
+			if got := req.Header.Get("Content-Type"); got != tt.wantContentType {
+				t.Errorf("SetXML() contentType = %v, want %v", got, tt.wantContentType)
+			}
+			got, _ := io.ReadAll(req.Body)
+			if string(got) != tt.wantBody {
+				t.Errorf("SetXML() = %v, want %v", string(got), tt.wantBody)
+			}
+		})
+	}
+}
+
+func Test_requestBuilder_SetXMLFromValue(t *testing.T) {
+	want := xmlBook{Title: "test"}
+	req := Builder().SetXMLFromValue(want, WithXMLHeader()).Request()
+	got := xmlBook{}
+	xml.NewDecoder(req.Body).Decode(&got)
+	want.XMLName = got.XMLName
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SetXMLFromValue() = %v, want %v", got, want)
+	}
+}